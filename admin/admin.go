@@ -0,0 +1,325 @@
+// Package admin implements an http.Handler to inspect and change the log configuration of a running
+// process.
+//
+// A GET request returns the registered handlers, their levels and the number of entries logged per
+// level since the Handler was built as JSON. A POST request with a JSON body changes the minimum level
+// of a handler:
+//
+//	{"handler": "*json.Handler", "min_level": "WARN"}
+//
+// or temporarily enables debug logging for a handler, or all handlers when omitted, after which their
+// previous levels are restored:
+//
+//	{"handler": "*json.Handler", "debug_minutes": 5}
+//
+// Handlers are identified by the name reported by log.Handlers. The levels of handlers whose dynamic
+// type is not comparable cannot be changed.
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/go-playground/log/v8"
+)
+
+// Builder is used to configure and create a new admin Handler
+type Builder struct {
+	l         *log.Instance
+	authorize func(r *http.Request) bool
+	counter   *log.LevelCounter
+}
+
+// NewBuilder creates a new Builder for the Default log Instance. Every request is passed to authorize
+// and rejected unless it returns true.
+func NewBuilder(authorize func(r *http.Request) bool) *Builder {
+	return &Builder{
+		authorize: authorize,
+	}
+}
+
+// WithInstance sets the log Instance to administer instead of the Default Instance.
+func (b *Builder) WithInstance(l *log.Instance) *Builder {
+	b.l = l
+	return b
+}
+
+// WithCounter sets an existing LevelCounter, already applied to the Instance, to report the number of
+// entries per level from.
+func (b *Builder) WithCounter(c *log.LevelCounter) *Builder {
+	b.counter = c
+	return b
+}
+
+// Build creates a new admin Handler. Unless an existing LevelCounter is set using WithCounter, a new one
+// is applied to the Instance as middleware to count the entries logged from now on. Middleware cannot be
+// removed, so Build must only be called once per Instance unless the counter is shared using WithCounter.
+func (b *Builder) Build() *Handler {
+	l := b.l
+	if l == nil {
+		l = log.Default()
+	}
+	counter := b.counter
+	if counter == nil {
+		counter = log.NewLevelCounter()
+		l.Use(counter.Middleware)
+	}
+	return &Handler{
+		l:         l,
+		counter:   counter,
+		authorize: b.authorize,
+		debug:     make(map[log.Handler]*debugState),
+		minute:    time.Minute,
+	}
+}
+
+// Handler is an http.Handler exposing and changing the log configuration, it can be mounted on any mux.
+type Handler struct {
+	l         *log.Instance
+	counter   *log.LevelCounter
+	authorize func(r *http.Request) bool
+	m         sync.Mutex
+	debug     map[log.Handler]*debugState
+	// minute is the unit of debug_minutes, shortened by tests.
+	minute time.Duration
+}
+
+// debugState is a handler with debug logging temporarily enabled.
+type debugState struct {
+	until   time.Time
+	timer   *time.Timer
+	restore func()
+}
+
+// Status is the response to every request.
+type Status struct {
+	Handlers []HandlerStatus   `json:"handlers"`
+	Counts   map[string]uint64 `json:"counts"`
+}
+
+// HandlerStatus describes a registered handler.
+type HandlerStatus struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Levels     []log.Level       `json:"levels"`
+	MinLevel   *log.Level        `json:"min_level,omitempty"`
+	Middleware int               `json:"middleware"`
+	Stats      map[string]uint64 `json:"stats,omitempty"`
+	DebugUntil *time.Time        `json:"debug_until,omitempty"`
+}
+
+// Request is the body of a POST request.
+type Request struct {
+	Handler      string     `json:"handler"`
+	MinLevel     *log.Level `json:"min_level"`
+	DebugMinutes int        `json:"debug_minutes"`
+}
+
+var (
+	errNotFound  = errors.New("handler not found")
+	errAmbiguous = errors.New("handler name is ambiguous")
+)
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.authorize == nil || !h.authorize(r) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := h.apply(req); err != nil {
+			switch err {
+			case errNotFound:
+				http.Error(w, err.Error(), http.StatusNotFound)
+			case errAmbiguous:
+				http.Error(w, err.Error(), http.StatusConflict)
+			default:
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			}
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.status())
+}
+
+func (h *Handler) status() Status {
+	l := h.l
+	s := Status{
+		Counts: make(map[string]uint64),
+	}
+	for level, n := range h.counter.Counts() {
+		s.Counts[level.String()] = n
+	}
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	for _, info := range l.Handlers() {
+		hs := HandlerStatus{
+			Name:       info.Name,
+			Type:       info.Type,
+			Levels:     info.Levels,
+			Middleware: info.Middleware,
+			Stats:      info.Stats,
+		}
+		if info.MinLevel != nil {
+			min := info.MinLevel.Level()
+			hs.MinLevel = &min
+		}
+		if identifiable(info.Handler) {
+			if d, found := h.debug[info.Handler]; found {
+				until := d.until
+				hs.DebugUntil = &until
+			}
+		}
+		s.Handlers = append(s.Handlers, hs)
+	}
+	return s
+}
+
+func (h *Handler) apply(req Request) error {
+	infos, err := h.find(req.Handler)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case req.DebugMinutes > 0:
+		for _, info := range infos {
+			h.enableDebug(info, time.Duration(req.DebugMinutes)*h.minute)
+		}
+	case req.MinLevel != nil:
+		if req.Handler == "" {
+			return errors.New("handler is required to set min_level")
+		}
+		if _, known := req.MinLevel.Definition(); !known {
+			return errors.New("unknown min_level")
+		}
+		h.setMinLevel(infos[0], *req.MinLevel)
+	default:
+		return errors.New("one of min_level or a positive debug_minutes is required")
+	}
+	return nil
+}
+
+// find returns the handler with the name, or all handlers when the name is empty.
+func (h *Handler) find(name string) ([]log.HandlerInfo, error) {
+	infos := h.l.Handlers()
+	if name == "" {
+		return infos, nil
+	}
+	var found []log.HandlerInfo
+	for _, info := range infos {
+		if info.Name == name {
+			found = append(found, info)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, errNotFound
+	case 1:
+		return found, nil
+	default:
+		return nil, errAmbiguous
+	}
+}
+
+func (h *Handler) setMinLevel(info log.HandlerInfo, level log.Level) {
+	if !identifiable(info.Handler) {
+		return
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	// an explicit change replaces any temporary debug logging
+	if d, found := h.debug[info.Handler]; found {
+		d.timer.Stop()
+		delete(h.debug, info.Handler)
+	}
+	setMinLevel(h.l, info, level)
+}
+
+func setMinLevel(l *log.Instance, info log.HandlerInfo, level log.Level) {
+	if info.MinLevel != nil {
+		info.MinLevel.Set(level)
+		return
+	}
+	l.SetHandlerLevels(info.Handler, log.LevelsAtLeast(level)...)
+}
+
+func (h *Handler) enableDebug(info log.HandlerInfo, d time.Duration) {
+	if !identifiable(info.Handler) {
+		return
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	state, found := h.debug[info.Handler]
+	if found {
+		// extend, keeping the levels from before debug was first enabled. A new state is used so that
+		// the previous timer does nothing should it have already fired.
+		state.timer.Stop()
+		state = &debugState{restore: state.restore}
+		h.debug[info.Handler] = state
+	} else {
+		l := h.l
+		state = &debugState{}
+		if info.MinLevel != nil {
+			min := info.MinLevel.Level()
+			state.restore = func() { info.MinLevel.Set(min) }
+		} else {
+			levels := info.Levels
+			state.restore = func() {
+				// a handler removed in the meantime has no levels and must not be registered again
+				if len(l.HandlerLevels(info.Handler)) > 0 {
+					l.SetHandlerLevels(info.Handler, levels...)
+				}
+			}
+		}
+		h.debug[info.Handler] = state
+		setMinLevel(l, info, log.DebugLevel)
+	}
+	state.until = time.Now().Add(d)
+	state.timer = time.AfterFunc(d, func() {
+		h.expire(info.Handler, state)
+	})
+}
+
+// expire restores the levels of a handler once its debug logging ends, unless it has since been extended
+// or replaced.
+func (h *Handler) expire(handler log.Handler, state *debugState) {
+	h.m.Lock()
+	defer h.m.Unlock()
+	if h.debug[handler] == state {
+		delete(h.debug, handler)
+		state.restore()
+	}
+}
+
+// identifiable reports whether the handler can be identified, handlers whose dynamic type is not
+// comparable cannot and so their levels cannot be changed.
+func identifiable(handler log.Handler) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return handler == handler
+}
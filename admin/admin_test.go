@@ -0,0 +1,216 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/go-playground/log/v8"
+	logjson "github.com/go-playground/log/v8/handlers/json"
+)
+
+func allow(*http.Request) bool { return true }
+
+func serve(t *testing.T, h http.Handler, method, body string) (int, Status) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, "/debug/log", strings.NewReader(body)))
+	var s Status
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &s); err != nil {
+			t.Fatalf("Unexpected error decoding '%s': %s", w.Body.String(), err)
+		}
+	}
+	return w.Code, s
+}
+
+func TestAuthorize(t *testing.T) {
+	l := log.New()
+	for _, authorize := range []func(*http.Request) bool{nil, func(*http.Request) bool { return false }} {
+		code, _ := serve(t, NewBuilder(authorize).WithInstance(l).Build(), http.MethodGet, "")
+		if code != http.StatusForbidden {
+			t.Errorf("Expected '%d' Got '%d'", http.StatusForbidden, code)
+		}
+	}
+
+	code, _ := serve(t, NewBuilder(allow).WithInstance(l).Build(), http.MethodDelete, "")
+	if code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected '%d' Got '%d'", http.StatusMethodNotAllowed, code)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	l := log.New()
+	console := log.NewConsoleBuilder().WithWriter(new(bytes.Buffer)).Build()
+	l.AddHandler(console, log.InfoLevel, log.ErrorLevel)
+	l.AddHandlerAtLeast(logjson.New(new(bytes.Buffer)), log.WarnLevel)
+	h := NewBuilder(allow).WithInstance(l).Build()
+	l.Info("one")
+	l.Info("two")
+	l.Error("three")
+
+	code, s := serve(t, h, http.MethodGet, "")
+	if code != http.StatusOK {
+		t.Fatalf("Expected '%d' Got '%d'", http.StatusOK, code)
+	}
+	if len(s.Handlers) != 2 {
+		t.Fatalf("Expected 2 handlers Got '%+v'", s.Handlers)
+	}
+	if hs := s.Handlers[0]; hs.Name != "*log.Logger" || hs.MinLevel != nil || !reflect.DeepEqual(hs.Levels, []log.Level{log.InfoLevel, log.ErrorLevel}) {
+		t.Errorf("Unexpected console status '%+v'", hs)
+	}
+	if hs := s.Handlers[1]; hs.Name != "*json.Handler" || hs.MinLevel == nil || *hs.MinLevel != log.WarnLevel || !reflect.DeepEqual(hs.Levels, log.LevelsAtLeast(log.WarnLevel)) {
+		t.Errorf("Unexpected json status '%+v'", hs)
+	}
+	if expected := map[string]uint64{"INFO": 2, "ERROR": 1}; !reflect.DeepEqual(s.Counts, expected) {
+		t.Errorf("Expected '%v' Got '%v'", expected, s.Counts)
+	}
+
+	// an existing counter is reported rather than applying another
+	c := log.NewLevelCounter()
+	l.Use(c.Middleware)
+	l.Warn("four")
+	_, s = serve(t, NewBuilder(allow).WithInstance(l).WithCounter(c).Build(), http.MethodGet, "")
+	if expected := map[string]uint64{"WARN": 1}; !reflect.DeepEqual(s.Counts, expected) {
+		t.Errorf("Expected '%v' Got '%v'", expected, s.Counts)
+	}
+}
+
+func TestSetMinLevel(t *testing.T) {
+	l := log.New()
+	console := log.NewConsoleBuilder().WithWriter(new(bytes.Buffer)).Build()
+	l.AddHandler(console, log.AllLevels...)
+	v := l.AddHandlerAtLeast(logjson.New(new(bytes.Buffer)), log.WarnLevel)
+	h := NewBuilder(allow).WithInstance(l).Build()
+
+	code, _ := serve(t, h, http.MethodPost, `{"handler":"*json.Handler","min_level":"ERROR"}`)
+	if code != http.StatusOK || v.Level() != log.ErrorLevel {
+		t.Errorf("Expected '%d' and ERROR Got '%d' and '%s'", http.StatusOK, code, v)
+	}
+
+	code, s := serve(t, h, http.MethodPost, `{"handler":"*log.Logger","min_level":"notice"}`)
+	if code != http.StatusOK || !reflect.DeepEqual(s.Handlers[0].Levels, log.LevelsAtLeast(log.NoticeLevel)) {
+		t.Errorf("Expected '%d' and '%v' Got '%d' and '%+v'", http.StatusOK, log.LevelsAtLeast(log.NoticeLevel), code, s.Handlers)
+	}
+
+	tests := []struct {
+		body     string
+		expected int
+	}{
+		{`{"handler":"*json.Handler","min_level":"BOGUS"}`, http.StatusBadRequest},
+		{`{"min_level":"ERROR"}`, http.StatusBadRequest},
+		{`{"handler":"*json.Handler"}`, http.StatusBadRequest},
+		{`{"handler":"*json.Handler"`, http.StatusBadRequest},
+		{`{"handler":"missing","min_level":"ERROR"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if code, _ := serve(t, h, http.MethodPost, tt.body); code != tt.expected {
+			t.Errorf("'%s' expected '%d' Got '%d'", tt.body, tt.expected, code)
+		}
+	}
+
+	l.AddHandler(logjson.New(new(bytes.Buffer)), log.ErrorLevel)
+	if code, _ := serve(t, h, http.MethodPost, `{"handler":"*json.Handler","min_level":"ERROR"}`); code != http.StatusConflict {
+		t.Errorf("Expected '%d' Got '%d'", http.StatusConflict, code)
+	}
+}
+
+func TestDebugMinutes(t *testing.T) {
+	l := log.New()
+	console := log.NewConsoleBuilder().WithWriter(new(bytes.Buffer)).Build()
+	l.AddHandler(console, log.ErrorLevel)
+	v := l.AddHandlerAtLeast(logjson.New(new(bytes.Buffer)), log.WarnLevel)
+	h := NewBuilder(allow).WithInstance(l).Build()
+	h.minute = 50 * time.Millisecond
+
+	code, s := serve(t, h, http.MethodPost, `{"debug_minutes":1}`)
+	if code != http.StatusOK {
+		t.Fatalf("Expected '%d' Got '%d'", http.StatusOK, code)
+	}
+	for _, hs := range s.Handlers {
+		if hs.DebugUntil == nil || hs.Levels[0] != log.DebugLevel {
+			t.Errorf("Expected debug enabled Got '%+v'", hs)
+		}
+	}
+	if v.Level() != log.DebugLevel {
+		t.Errorf("Expected '%s' Got '%s'", log.DebugLevel, v)
+	}
+
+	// extending keeps the levels to restore
+	if code, _ = serve(t, h, http.MethodPost, `{"handler":"*log.Logger","debug_minutes":2}`); code != http.StatusOK {
+		t.Fatalf("Expected '%d' Got '%d'", http.StatusOK, code)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, s = serve(t, h, http.MethodGet, "")
+		if s.Handlers[0].DebugUntil == nil && s.Handlers[1].DebugUntil == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected debug to be restored Got '%+v'", s.Handlers)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if levels := l.HandlerLevels(console); !reflect.DeepEqual(levels, []log.Level{log.ErrorLevel}) {
+		t.Errorf("Expected '%v' Got '%v'", []log.Level{log.ErrorLevel}, levels)
+	}
+	if v.Level() != log.WarnLevel {
+		t.Errorf("Expected '%s' Got '%s'", log.WarnLevel, v)
+	}
+}
+
+func TestDebugMinutesExtendAfterExpiry(t *testing.T) {
+	l := log.New()
+	console := log.NewConsoleBuilder().WithWriter(new(bytes.Buffer)).Build()
+	l.AddHandler(console, log.ErrorLevel)
+	h := NewBuilder(allow).WithInstance(l).Build()
+	h.minute = time.Hour
+
+	if code, _ := serve(t, h, http.MethodPost, `{"debug_minutes":1}`); code != http.StatusOK {
+		t.Fatalf("Expected '%d' Got '%d'", http.StatusOK, code)
+	}
+	stale := h.debug[console]
+	if code, _ := serve(t, h, http.MethodPost, `{"debug_minutes":2}`); code != http.StatusOK {
+		t.Fatalf("Expected '%d' Got '%d'", http.StatusOK, code)
+	}
+
+	// the first timer fired while debug logging was being extended
+	h.expire(console, stale)
+	if levels := l.HandlerLevels(console); levels[0] != log.DebugLevel {
+		t.Errorf("Expected debug to remain enabled Got '%v'", levels)
+	}
+
+	state := h.debug[console]
+	state.timer.Stop()
+	h.expire(console, state)
+	if levels := l.HandlerLevels(console); !reflect.DeepEqual(levels, []log.Level{log.ErrorLevel}) {
+		t.Errorf("Expected '%v' Got '%v'", []log.Level{log.ErrorLevel}, levels)
+	}
+}
+
+type valueHandler struct {
+	_ []byte
+}
+
+func (valueHandler) Log(log.Entry) {}
+
+func TestNonComparableHandler(t *testing.T) {
+	l := log.New()
+	l.AddHandler(valueHandler{}, log.InfoLevel)
+	h := NewBuilder(allow).WithInstance(l).Build()
+
+	code, s := serve(t, h, http.MethodPost, `{"debug_minutes":1}`)
+	if code != http.StatusOK || len(s.Handlers) != 1 || s.Handlers[0].DebugUntil != nil {
+		t.Errorf("Expected '%d' and debug not enabled Got '%d' and '%+v'", http.StatusOK, code, s.Handlers)
+	}
+	code, s = serve(t, h, http.MethodPost, `{"handler":"admin.valueHandler","min_level":"ERROR"}`)
+	if code != http.StatusOK || !reflect.DeepEqual(s.Handlers[0].Levels, []log.Level{log.InfoLevel}) {
+		t.Errorf("Expected '%d' and levels unchanged Got '%d' and '%+v'", http.StatusOK, code, s.Handlers)
+	}
+}
@@ -0,0 +1,256 @@
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Overflow is the behaviour of an AsyncHandler when its queue is full.
+type Overflow uint8
+
+// Overflow policies.
+const (
+	// OverflowBlock blocks the logging call until there is room in the queue.
+	OverflowBlock Overflow = iota
+	// OverflowDropNewest drops the entry being logged.
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest queued entry to make room for the one being logged.
+	OverflowDropOldest
+	// OverflowDropBelowLevel drops the entry being logged if it is below the configured drop level,
+	// otherwise it blocks until there is room in the queue.
+	OverflowDropBelowLevel
+)
+
+// AsyncBuilder is used to configure and create a new AsyncHandler
+type AsyncBuilder struct {
+	queueSize int
+	overflow  Overflow
+	dropLevel Level
+}
+
+// NewAsyncBuilder creates a new AsyncBuilder with a queue size of 1024 that blocks when full.
+func NewAsyncBuilder() *AsyncBuilder {
+	return &AsyncBuilder{
+		queueSize: 1024,
+		overflow:  OverflowBlock,
+		dropLevel: WarnLevel,
+	}
+}
+
+// WithQueueSize sets the maximum number of entries buffered before the overflow policy applies, sizes
+// below 1 are treated as 1 as an unbuffered queue leaves no room for OverflowDropOldest to make.
+func (b *AsyncBuilder) WithQueueSize(size int) *AsyncBuilder {
+	if size < 1 {
+		size = 1
+	}
+	b.queueSize = size
+	return b
+}
+
+// WithOverflow sets the policy applied when the queue is full.
+func (b *AsyncBuilder) WithOverflow(overflow Overflow) *AsyncBuilder {
+	b.overflow = overflow
+	return b
+}
+
+// WithDropLevel sets the level, by severity, below which entries are dropped when using OverflowDropBelowLevel.
+func (b *AsyncBuilder) WithDropLevel(level Level) *AsyncBuilder {
+	b.dropLevel = level
+	return b
+}
+
+// Build wraps the provided Handler so that its entries are processed on a separate goroutine.
+func (b *AsyncBuilder) Build(h Handler) *AsyncHandler {
+	a := &AsyncHandler{
+		handler:   h,
+		queue:     make(chan asyncItem, b.queueSize),
+		done:      make(chan struct{}),
+		overflow:  b.overflow,
+		dropLevel: b.dropLevel,
+	}
+	go a.run()
+	return a
+}
+
+// AsyncHandler is a Handler that queues entries and passes them to the wrapped Handler on a separate goroutine.
+type AsyncHandler struct {
+	dropped   uint64
+	m         sync.RWMutex
+	closed    bool
+	handler   Handler
+	queue     chan asyncItem
+	done      chan struct{}
+	overflow  Overflow
+	dropLevel Level
+}
+
+// asyncItem is either an Entry to log or, when flushed is non-nil, a marker that is
+// signalled once every item queued before it has been processed.
+type asyncItem struct {
+	entry   Entry
+	flushed chan struct{}
+}
+
+func (a *AsyncHandler) run() {
+	for item := range a.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		item.entry.logger().safeLog(a.handler, item.entry)
+	}
+	close(a.done)
+}
+
+// Log queues the log entry, applying the overflow policy when the queue is full.
+// Once closed entries are passed to the wrapped Handler synchronously.
+func (a *AsyncHandler) Log(e Entry) {
+	a.m.RLock()
+	defer a.m.RUnlock()
+
+	if a.closed {
+		a.handler.Log(e)
+		return
+	}
+	e = e.retain()
+
+	switch a.overflow {
+	case OverflowDropNewest:
+		a.trySend(e)
+
+	case OverflowDropOldest:
+		for {
+			select {
+			case a.queue <- asyncItem{entry: e}:
+				return
+			default:
+			}
+			select {
+			case item := <-a.queue:
+				if item.flushed != nil {
+					// everything queued before the marker has already been processed
+					close(item.flushed)
+					continue
+				}
+				atomic.AddUint64(&a.dropped, 1)
+			default:
+			}
+		}
+
+	case OverflowDropBelowLevel:
+		if e.Level.Severity() < a.dropLevel.Severity() {
+			a.trySend(e)
+			return
+		}
+		a.queue <- asyncItem{entry: e}
+
+	default:
+		a.queue <- asyncItem{entry: e}
+	}
+}
+
+func (a *AsyncHandler) trySend(e Entry) {
+	select {
+	case a.queue <- asyncItem{entry: e}:
+	default:
+		atomic.AddUint64(&a.dropped, 1)
+	}
+}
+
+// Dropped returns the number of entries dropped due to the overflow policy.
+func (a *AsyncHandler) Dropped() uint64 {
+	return atomic.LoadUint64(&a.dropped)
+}
+
+// Name returns the name of the wrapped Handler.
+func (a *AsyncHandler) Name() string {
+	return "async(" + handlerName(a.handler) + ")"
+}
+
+// Stats returns the number of entries dropped and currently queued.
+func (a *AsyncHandler) Stats() map[string]uint64 {
+	return map[string]uint64{
+		"dropped": a.Dropped(),
+		"queued":  uint64(len(a.queue)),
+	}
+}
+
+// Handler returns the wrapped Handler.
+func (a *AsyncHandler) Handler() Handler {
+	return a.handler
+}
+
+// Flush blocks until all entries queued before the call have been processed and then flushes the
+// wrapped Handler if it implements Flusher.
+func (a *AsyncHandler) Flush() error {
+	a.m.RLock()
+	if a.closed {
+		a.m.RUnlock()
+		<-a.done
+	} else {
+		flushed := make(chan struct{})
+		a.queue <- asyncItem{flushed: flushed}
+		a.m.RUnlock()
+		<-flushed
+	}
+	if f, ok := a.handler.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close stops accepting queued entries, blocks until all queued entries have been processed and then
+// closes the wrapped Handler if it implements Closer.
+func (a *AsyncHandler) Close() error {
+	a.drain()
+	if c, ok := a.handler.(Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// drain stops accepting queued entries and blocks until all queued entries have been processed.
+func (a *AsyncHandler) drain() {
+	a.m.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.m.Unlock()
+	<-a.done
+}
+
+// dispatchHandler adapts an Instance's synchronous fan-out to the Handler interface for global async dispatch.
+type dispatchHandler struct {
+	l *Instance
+}
+
+func (d dispatchHandler) Log(e Entry) {
+	d.l.dispatch(e)
+}
+
+// SetAsync enables asynchronous dispatch of all entries for this Instance using the supplied configuration,
+// returning the AsyncHandler now in use so its counters can be inspected. Passing nil restores synchronous
+// dispatch. Any previously configured async queue is drained before returning.
+func (l *Instance) SetAsync(b *AsyncBuilder) *AsyncHandler {
+	var a *AsyncHandler
+	if b != nil {
+		a = b.Build(dispatchHandler{l: l})
+	}
+	var prev *AsyncHandler
+	l.update(func(s *snapshot) {
+		prev = s.async
+		s.async = a
+	})
+
+	if prev != nil {
+		prev.drain()
+	}
+	return a
+}
+
+// SetAsync enables asynchronous dispatch of all entries for the Default Instance.
+// see Instance.SetAsync for details.
+func SetAsync(b *AsyncBuilder) *AsyncHandler {
+	return Default().SetAsync(b)
+}
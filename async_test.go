@@ -0,0 +1,146 @@
+package log
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+type blockingHandler struct {
+	m       sync.Mutex
+	release chan struct{}
+	started chan struct{}
+	msgs    []string
+}
+
+func (h *blockingHandler) Log(e Entry) {
+	select {
+	case h.started <- struct{}{}:
+	default:
+	}
+	<-h.release
+	h.m.Lock()
+	h.msgs = append(h.msgs, e.Message)
+	h.m.Unlock()
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{
+		release: make(chan struct{}),
+		started: make(chan struct{}, 1),
+	}
+}
+
+func TestAsyncOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		overflow Overflow
+		entries  []Entry
+		want     []string
+		dropped  uint64
+	}{
+		{
+			name:     "drop-newest",
+			overflow: OverflowDropNewest,
+			entries:  []Entry{{Message: "2"}, {Message: "3"}, {Message: "4"}},
+			want:     []string{"1", "2", "3"},
+			dropped:  1,
+		},
+		{
+			name:     "drop-oldest",
+			overflow: OverflowDropOldest,
+			entries:  []Entry{{Message: "2"}, {Message: "3"}, {Message: "4"}},
+			want:     []string{"1", "3", "4"},
+			dropped:  1,
+		},
+		{
+			name:     "drop-below-level",
+			overflow: OverflowDropBelowLevel,
+			entries:  []Entry{{Message: "2"}, {Message: "3"}, {Message: "4", Level: DebugLevel}},
+			want:     []string{"1", "2", "3"},
+			dropped:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newBlockingHandler()
+			a := NewAsyncBuilder().WithQueueSize(2).WithOverflow(tt.overflow).WithDropLevel(InfoLevel).Build(h)
+
+			a.Log(Entry{Message: "1"})
+			<-h.started // first entry is being processed, queue is now empty
+
+			for _, e := range tt.entries {
+				a.Log(e)
+			}
+			close(h.release)
+			_ = a.Close()
+
+			if a.Dropped() != tt.dropped {
+				t.Errorf("Expected '%d' dropped Got '%d'", tt.dropped, a.Dropped())
+			}
+			if len(h.msgs) != len(tt.want) {
+				t.Fatalf("Expected '%v' Got '%v'", tt.want, h.msgs)
+			}
+			for i := range tt.want {
+				if h.msgs[i] != tt.want[i] {
+					t.Errorf("Expected '%v' Got '%v'", tt.want, h.msgs)
+				}
+			}
+		})
+	}
+}
+
+func TestAsyncQueueSize(t *testing.T) {
+	for _, size := range []int{-1, 0} {
+		h := newBlockingHandler()
+		a := NewAsyncBuilder().WithQueueSize(size).WithOverflow(OverflowDropOldest).Build(h)
+		if cap(a.queue) != 1 {
+			t.Errorf("%d: Expected queue size 1 Got %d", size, cap(a.queue))
+		}
+
+		a.Log(Entry{Message: "1"})
+		<-h.started
+		// the consumer is busy so these must replace each other rather than spin
+		a.Log(Entry{Message: "2"})
+		a.Log(Entry{Message: "3"})
+		close(h.release)
+		_ = a.Close()
+
+		if expected := []string{"1", "3"}; len(h.msgs) != 2 || h.msgs[0] != expected[0] || h.msgs[1] != expected[1] {
+			t.Errorf("%d: Expected '%v' Got '%v'", size, expected, h.msgs)
+		}
+		if a.Dropped() != 1 {
+			t.Errorf("%d: Expected '%d' dropped Got '%d'", size, 1, a.Dropped())
+		}
+	}
+}
+
+func TestAsyncGlobal(t *testing.T) {
+	SetDefault(New())
+	buff := new(bytes.Buffer)
+	AddHandler(&testHandler{writer: buff}, AllLevels...)
+
+	a := SetAsync(NewAsyncBuilder().WithQueueSize(10))
+	Info("async")
+	_ = a.Close()
+	if buff.String() != "INFO async\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO async\n", buff.String())
+	}
+
+	// closed async handlers pass through synchronously until replaced
+	buff.Reset()
+	Info("closed")
+	if buff.String() != "INFO closed\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO closed\n", buff.String())
+	}
+
+	if SetAsync(nil) != nil {
+		t.Error("expected nil AsyncHandler when disabling")
+	}
+	buff.Reset()
+	Info("sync")
+	if buff.String() != "INFO sync\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO sync\n", buff.String())
+	}
+}
@@ -59,6 +59,29 @@ func BenchmarkLogConsoleTenFieldsParallel(b *testing.B) {
 	})
 }
 
+func BenchmarkLogConsoleTenTypedFieldsParallel(b *testing.B) {
+	log.AddHandler(log.NewConsoleBuilder().WithWriter(ioutil.Discard).Build(), log.AllLevels...)
+	b.ResetTimer()
+	// log setup in TestMain
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			log.WithFields(
+				log.Int64("int", 1),
+				log.Int64("int64", 1),
+				log.F("float", 3.0),
+				log.String("string", "four!"),
+				log.Bool("bool", true),
+				log.Time("time", time.Unix(0, 0)),
+				log.Err(errExample),
+				log.Duration("duration", time.Second),
+				log.F("user-defined type", _jane),
+				log.String("another string", "done!"),
+			).Info("Go fast.")
+		}
+
+	})
+}
+
 func BenchmarkLogConsoleSimpleParallel(b *testing.B) {
 
 	log.AddHandler(log.NewConsoleBuilder().WithWriter(ioutil.Discard).Build(), log.AllLevels...)
@@ -84,3 +107,35 @@ func BenchmarkLogJSONSimpleParallel(b *testing.B) {
 
 	})
 }
+
+func BenchmarkLogConsolePooledTypedFields(b *testing.B) {
+	l := log.New()
+	l.AddHandler(log.NewConsoleBuilder().WithWriter(ioutil.Discard).Build(), log.AllLevels...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Acquire().With(
+			log.String("string", "four!"),
+			log.Int64("int64", 1),
+			log.Bool("bool", true),
+			log.Time("time", _jane.CreatedAt),
+			log.Err(errExample),
+		).Info("Go fast.")
+	}
+}
+
+func BenchmarkLogJSONPooledTypedFields(b *testing.B) {
+	l := log.New()
+	l.AddHandler(json.New(ioutil.Discard), log.AllLevels...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Acquire().With(
+			log.String("string", "four!"),
+			log.Int64("int64", 1),
+			log.Bool("bool", true),
+			log.Time("time", _jane.CreatedAt),
+			log.Err(errExample),
+		).Info("Go fast.")
+	}
+}
@@ -2,8 +2,14 @@ package log
 
 import (
 	"bytes"
+	"context"
 	stderr "errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-playground/errors/v5"
 )
@@ -47,3 +53,114 @@ func BenchmarkWithErrorExistingParallel(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkDisabledLevel(b *testing.B) {
+	l := New()
+	l.WithDefaultFields(F("app", "bench"))
+	l.AddHandler(&testHandler{writer: io.Discard}, InfoLevel)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Debugf("disabled %d", i)
+	}
+}
+
+func BenchmarkDisabledLevelParallel(b *testing.B) {
+	l := New()
+	l.WithDefaultFields(F("app", "bench"))
+	l.AddHandler(&testHandler{writer: io.Discard}, InfoLevel)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.WithField("key", "value").Debug("disabled")
+		}
+	})
+}
+
+type nopHandler struct{}
+
+func (nopHandler) Log(Entry) {}
+
+// rwMutexTable mirrors the previous RWMutex protected handler map, used as a baseline.
+type rwMutexTable struct {
+	rw       sync.RWMutex
+	handlers map[Level][]Handler
+}
+
+func (t *rwMutexTable) handle(e Entry) {
+	t.rw.RLock()
+	for _, h := range t.handlers[e.Level] {
+		h.Log(e)
+	}
+	t.rw.RUnlock()
+}
+
+func benchmarkAcrossProcs(b *testing.B, fn func(pb *testing.PB)) {
+	for _, procs := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("GOMAXPROCS=%d", procs), func(b *testing.B) {
+			prev := runtime.GOMAXPROCS(procs)
+			defer runtime.GOMAXPROCS(prev)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(fn)
+		})
+	}
+}
+
+func BenchmarkHandleEntryContention(b *testing.B) {
+	l := New()
+	l.AddHandler(nopHandler{}, AllLevels...)
+	e := Entry{Level: InfoLevel, Timestamp: time.Now()}
+	benchmarkAcrossProcs(b, func(pb *testing.PB) {
+		for pb.Next() {
+			l.HandleEntry(e)
+		}
+	})
+}
+
+func BenchmarkHandleEntryContentionRWMutexBaseline(b *testing.B) {
+	t := &rwMutexTable{handlers: map[Level][]Handler{}}
+	for _, lvl := range AllLevels {
+		t.handlers[lvl] = []Handler{nopHandler{}}
+	}
+	e := Entry{Level: InfoLevel, Timestamp: time.Now()}
+	benchmarkAcrossProcs(b, func(pb *testing.PB) {
+		for pb.Next() {
+			t.handle(e)
+		}
+	})
+}
+
+func benchmarkPIIScan(b *testing.B, e Entry) {
+	p := NewPIIScannerBuilder().Build()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = p.Scan(e)
+	}
+}
+
+func BenchmarkPIIScanClean(b *testing.B) {
+	benchmarkPIIScan(b, Entry{
+		Message: "request completed",
+		Fields:  []Field{F("path", "/api/v1/users"), F("status", 200), F("method", "GET")},
+	})
+}
+
+func BenchmarkPIIScanDirty(b *testing.B) {
+	benchmarkPIIScan(b, Entry{
+		Message: "login for joeybloggs@example.com from 192.168.1.10",
+		Fields:  []Field{F("path", "/api/v1/users"), F("authorization", "Bearer abc123"), F("card", "4111 1111 1111 1111")},
+	})
+}
+
+func BenchmarkFlightRecorderStart(b *testing.B) {
+	rec := NewFlightRecorderBuilder().Build()
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, end := rec.Start(ctx)
+		end()
+	}
+}
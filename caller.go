@@ -0,0 +1,73 @@
+package log
+
+import (
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+// SetCallerLevels sets the levels for which the Default Instance records the calling frame of every entry.
+// see Instance.SetCallerLevels for details.
+func SetCallerLevels(levels ...Level) {
+	Default().SetCallerLevels(levels...)
+}
+
+// WithCaller returns a new log entry that records the calling frame when logged.
+func WithCaller() Entry {
+	return Default().WithCaller()
+}
+
+// SetCallerLevels sets the levels for which the calling frame is recorded in Entry.Caller for every entry,
+// replacing any previously set. Call with no levels to disable.
+func (l *Instance) SetCallerLevels(levels ...Level) {
+	l.update(func(s *snapshot) {
+		s.caller = levelSet{}
+		for _, lvl := range levels {
+			s.caller.add(lvl)
+		}
+	})
+}
+
+// WithCaller returns a new log entry that records the calling frame when logged.
+func (l *Instance) WithCaller() Entry {
+	ne := l.newEntry()
+	ne.withCaller = true
+	return ne
+}
+
+// WithCaller returns a new log entry that records the calling frame when logged, regardless of level.
+func (e Entry) WithCaller() Entry {
+	e.withCaller = true
+	return e
+}
+
+// newLevelEntry returns a new log entry for use by the level functions of the package and Instance,
+// which add an extra frame between the caller and the Entry level method.
+func (l *Instance) newLevelEntry() Entry {
+	e := l.newEntry()
+	e.callerSkip = 1
+	return e
+}
+
+// addSource records the calling frame and stack traces if requested for the entry or its level.
+// It must only be called directly from the Entry level methods.
+func (e *Entry) addSource(l *Instance) {
+	s := l.load()
+	if e.withCaller || s.callerFor(e.Level) {
+		e.Caller = formatCaller(runtimeext.StackLevel(2 + e.callerSkip))
+	}
+	if s.stackFor(e.Level) {
+		// full slice expression to avoid modifying fields shared with the parent entry
+		e.Fields = append(e.Fields[:len(e.Fields):len(e.Fields)], F("stack", stackTrace(4+e.callerSkip)))
+		if e.Level == FatalLevel && s.allGoroutines {
+			e.Fields = append(e.Fields, F("goroutines", allGoroutines()))
+		}
+	}
+}
+
+// formatCaller formats the frame in the same package/file:line:function form as WithError.
+func formatCaller(frame runtimeext.Frame) string {
+	buff := BytePool().Get()
+	buff.B = extractSource(buff.B, frame)
+	s := string(buff.B[:len(buff.B)-1])
+	BytePool().Put(buff)
+	return s
+}
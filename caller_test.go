@@ -0,0 +1,116 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type callerHandler struct {
+	callers []string
+}
+
+func (h *callerHandler) Log(e Entry) {
+	h.callers = append(h.callers, e.Caller)
+}
+
+func TestCaller(t *testing.T) {
+	SetDefault(New())
+	SetExitFunc(func(int) {})
+	h := &callerHandler{}
+	AddHandler(h, AllLevels...)
+
+	Info("no caller")
+	WithCaller().Info("entry")
+	SetCallerLevels(DebugLevel, FatalLevel)
+	Debug("package")
+	Debugf("package %s", "f")
+	WithField("key", "value").Debug("entry method")
+	Default().Fatal("instance")
+	Info("level not enabled")
+	SetCallerLevels()
+	Debug("disabled")
+
+	expected := []string{
+		"",
+		"log/v8/caller_test.go:24:TestCaller",
+		"log/v8/caller_test.go:26:TestCaller",
+		"log/v8/caller_test.go:27:TestCaller",
+		"log/v8/caller_test.go:28:TestCaller",
+		"log/v8/caller_test.go:29:TestCaller",
+		"",
+		"",
+	}
+	if len(h.callers) != len(expected) {
+		t.Fatalf("Expected '%d' entries Got '%d'", len(expected), len(h.callers))
+	}
+	for i, want := range expected {
+		if want == "" && h.callers[i] != "" || !strings.HasSuffix(h.callers[i], want) {
+			t.Errorf("entry %d: Expected '%s' Got '%s'", i, want, h.callers[i])
+		}
+	}
+}
+
+func TestConsoleCaller(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(NewConsoleBuilder().WithWriter(buff).WithTimestampFormat("").Build(), AllLevels...)
+	l.WithCaller().WithField("key", "value").Info("info")
+	expected := "caller_test.go:58:TestConsoleCaller key=value\n"
+	if !strings.HasPrefix(buff.String(), "   INFO info caller=github.com/go-playground/log/v8/") || !strings.HasSuffix(buff.String(), expected) {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+type fieldsHandler struct {
+	entries []Entry
+}
+
+func (h *fieldsHandler) Log(e Entry) {
+	h.entries = append(h.entries, e)
+}
+
+func TestStackTrace(t *testing.T) {
+	l := New()
+	l.SetExitFunc(func(int) {})
+	h := &fieldsHandler{}
+	l.AddHandler(h, AllLevels...)
+	l.SetStackTraceLevels(PanicLevel, AlertLevel, FatalLevel)
+	l.SetFatalAllGoroutines(true)
+
+	parent := l.WithField("key", "value")
+	parent.Alert("alert")
+	l.Fatal("fatal")
+	l.Error("error")
+
+	if len(h.entries) != 3 {
+		t.Fatalf("Expected '%d' entries Got '%d'", 3, len(h.entries))
+	}
+	if len(parent.Fields) != 1 {
+		t.Errorf("Expected parent entry fields to be unmodified Got '%v'", parent.Fields)
+	}
+
+	alert := h.entries[0]
+	if len(alert.Fields) != 2 || alert.Fields[1].Key != "stack" {
+		t.Fatalf("Expected stack field Got '%v'", alert.Fields)
+	}
+	stack := alert.Fields[1].Value.([]string)
+	if !strings.HasSuffix(stack[0], "caller_test.go:82:TestStackTrace") || !strings.Contains(stack[1], "testing/testing.go") {
+		t.Errorf("Expected stack to start at caller Got '%v'", stack)
+	}
+
+	fatal := h.entries[1]
+	if len(fatal.Fields) != 2 || fatal.Fields[0].Key != "stack" || fatal.Fields[1].Key != "goroutines" {
+		t.Fatalf("Expected stack and goroutines fields Got '%v'", fatal.Fields)
+	}
+	if !strings.HasSuffix(fatal.Fields[0].Value.([]string)[0], "caller_test.go:83:TestStackTrace") {
+		t.Errorf("Expected stack to start at caller Got '%v'", fatal.Fields[0].Value)
+	}
+	if !strings.HasPrefix(fatal.Fields[1].Value.(string), "goroutine ") {
+		t.Errorf("Expected goroutine dump Got '%s'", fatal.Fields[1].Value)
+	}
+
+	if len(h.entries[2].Fields) != 0 {
+		t.Errorf("Expected no stack for error level Got '%v'", h.entries[2].Fields)
+	}
+}
@@ -0,0 +1,70 @@
+package log
+
+import "context"
+
+// AddContextExtractor registers a function with the Default Instance that extracts fields from a context.
+// see Instance.AddContextExtractor for details.
+func AddContextExtractor(fn func(ctx context.Context) []Field) {
+	Default().AddContextExtractor(fn)
+}
+
+// Ctx returns the log Entry found in the context, or a new Default log Entry if none is found, with the
+// fields of any registered context extractors added. It is shorthand for GetContext.
+func Ctx(ctx context.Context) Entry {
+	return Default().GetContext(ctx)
+}
+
+// AddContextExtractor registers a function that extracts fields, such as request or tenant IDs carried
+// by other middleware, from a context. The fields are added, in registration order, to entries returned
+// by GetContext and Ctx. Extracted fields whose key is already present on the entry are skipped, so
+// storing the returned entry back using SetContext does not duplicate them.
+func (l *Instance) AddContextExtractor(fn func(ctx context.Context) []Field) {
+	l.update(func(s *snapshot) {
+		extractors := make([]func(context.Context) []Field, len(s.extractors), len(s.extractors)+1)
+		copy(extractors, s.extractors)
+		s.extractors = append(extractors, fn)
+	})
+}
+
+// GetContext returns the log Entry found in the context, or a new log Entry from this Instance if none
+// is found, with the fields of any registered context extractors added. If the context was started by
+// a FlightRecorder the entry is recorded by it.
+func (l *Instance) GetContext(ctx context.Context) Entry {
+	e, ok := ctx.Value(ctxIdent).(Entry)
+	if !ok {
+		e = l.newEntry()
+	}
+	if rec := flightRecordingFromContext(ctx); rec != nil {
+		e.recording = rec
+	}
+	return e.withContextFields(ctx)
+}
+
+// Ctx is shorthand for GetContext.
+func (l *Instance) Ctx(ctx context.Context) Entry {
+	return l.GetContext(ctx)
+}
+
+// withContextFields adds the fields of the entry's Instance context extractors.
+func (e Entry) withContextFields(ctx context.Context) Entry {
+	extractors := e.logger().load().extractors
+	if len(extractors) == 0 {
+		return e
+	}
+	var fields []Field
+	for _, fn := range extractors {
+	OUTER:
+		for _, f := range fn(ctx) {
+			for _, existing := range e.Fields {
+				if existing.Key == f.Key {
+					continue OUTER
+				}
+			}
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return e
+	}
+	return e.clone(fields...)
+}
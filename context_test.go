@@ -0,0 +1,51 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+type requestIDKey struct{}
+
+func TestContextExtractors(t *testing.T) {
+	SetDefault(New())
+	buff := new(bytes.Buffer)
+	AddHandler(&testHandler{writer: buff}, AllLevels...)
+	AddContextExtractor(func(ctx context.Context) []Field {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			return []Field{F("request_id", id)}
+		}
+		return nil
+	})
+
+	ctx := context.Background()
+	Ctx(ctx).Info("none")
+	if buff.String() != "INFO none\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO none\n", buff.String())
+	}
+
+	buff.Reset()
+	ctx = context.WithValue(ctx, requestIDKey{}, "abc")
+	Ctx(ctx).Info("extracted")
+	if buff.String() != "INFO extracted request_id=abc\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO extracted request_id=abc\n", buff.String())
+	}
+
+	// storing the entry back into the context must not duplicate extracted fields
+	buff.Reset()
+	ctx = SetContext(ctx, GetContext(ctx).WithField("key", "value"))
+	GetContext(ctx).Info("stored")
+	if buff.String() != "INFO stored request_id=abc key=value\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO stored request_id=abc key=value\n", buff.String())
+	}
+
+	// extractors belong to the Instance
+	buff.Reset()
+	l := New()
+	l.AddHandler(&testHandler{writer: buff}, AllLevels...)
+	l.Ctx(context.WithValue(context.Background(), requestIDKey{}, "abc")).Info("instance")
+	if buff.String() != "INFO instance\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO instance\n", buff.String())
+	}
+}
@@ -59,12 +59,12 @@ func (c *Logger) Log(e Entry) {
 	var lvl string
 	var i int
 	buff := BytePool().Get()
-	buff.B = append(buff.B, e.Timestamp.Format(c.timestampFormat)...)
+	buff.B = e.Timestamp.AppendFormat(buff.B, c.timestampFormat)
 	buff.B = append(buff.B, space)
 
 	lvl = e.Level.String()
 
-	for i = 0; i < 6-len(lvl); i++ {
+	for i = 0; i < levelNameWidth()-len(lvl); i++ {
 		buff.B = append(buff.B, space)
 	}
 
@@ -72,19 +72,32 @@ func (c *Logger) Log(e Entry) {
 	buff.B = append(buff.B, space)
 	buff.B = append(buff.B, e.Message...)
 
+	if e.Caller != "" {
+		printKey(buff, "caller")
+		buff.B = append(buff.B, e.Caller...)
+	}
 	c.addFields("", buff, e.Fields)
 	buff.B = append(buff.B, newLine)
 
 	c.m.Lock()
-	_, _ = c.writer.Write(buff.B)
+	_, err := c.writer.Write(buff.B)
 	c.m.Unlock()
 
 	BytePool().Put(buff)
+	if err != nil {
+		ReportError(c, e, err)
+	}
 }
 
 func (c *Logger) addFields(prefix string, buff *Buffer, fields []Field) {
 	for _, f := range fields {
 
+		if f.Kind() != KindAny {
+			printKey(buff, prefix+f.Key)
+			c.addTypedValue(buff, f)
+			continue
+		}
+
 		switch t := f.Value.(type) {
 		case string:
 			printKey(buff, prefix+f.Key)
@@ -137,6 +150,28 @@ func (c *Logger) addFields(prefix string, buff *Buffer, fields []Field) {
 	}
 }
 
+// addTypedValue adds the value of a Field created using one of the typed constructors.
+func (c *Logger) addTypedValue(buff *Buffer, f Field) {
+	switch f.Kind() {
+	case KindString, KindBytes:
+		buff.B = append(buff.B, f.StringValue()...)
+	case KindInt64:
+		buff.B = strconv.AppendInt(buff.B, f.Int64Value(), base10)
+	case KindBool:
+		buff.B = strconv.AppendBool(buff.B, f.BoolValue())
+	case KindDuration:
+		buff.B = append(buff.B, f.DurationValue().String()...)
+	case KindTime:
+		buff.B = f.TimeValue().AppendFormat(buff.B, c.timestampFormat)
+	case KindError:
+		if err := f.ErrorValue(); err != nil {
+			buff.B = append(buff.B, err.Error()...)
+		} else {
+			buff.B = append(buff.B, "<nil>"...)
+		}
+	}
+}
+
 func printKey(buff *Buffer, key string) {
 	buff.B = append(buff.B, space)
 	buff.B = append(buff.B, key...)
@@ -14,7 +14,31 @@ type Entry struct {
 	Timestamp time.Time `json:"timestamp"`
 	Fields    []Field   `json:"fields"`
 	Level     Level     `json:"level"`
-	start     time.Time
+	// Caller is the package/file:line:function the entry was logged from, it is only set when
+	// requested using WithCaller or SetCallerLevels.
+	Caller     string `json:"caller,omitempty"`
+	start      time.Time
+	instance   *Instance
+	withCaller bool
+	callerSkip int
+	// recording is the flight recording of the context the entry was retrieved from, if any.
+	recording *flightRecording
+	// replayed marks entries at a level buffered by a triggered flight recording, they are passed to the
+	// handlers of triggerLevel rather than those of their own level.
+	replayed     bool
+	triggerLevel Level
+	// pooled marks entries whose fields belong to a PooledEntry and are reused once handled.
+	pooled bool
+	// sampleSummary marks entries summarizing those suppressed by a Sampler so they are never sampled.
+	sampleSummary bool
+}
+
+// logger returns the Instance the Entry was created from or the Default Instance.
+func (e Entry) logger() *Instance {
+	if e.instance == nil {
+		return Default()
+	}
+	return e.instance
 }
 
 func (e Entry) clone(fields ...Field) Entry {
@@ -24,12 +48,14 @@ func (e Entry) clone(fields ...Field) Entry {
 	return e
 }
 
-func newEntry(fields ...Field) Entry {
-	e := Entry{
-		Fields: make([]Field, len(fields)+len(logFields)),
+// retain returns the entry with its own copy of the fields of a PooledEntry, it must be called by anything
+// holding on to an entry after it has been handled.
+func (e Entry) retain() Entry {
+	if !e.pooled {
+		return e
 	}
-	copy(e.Fields[copy(e.Fields, logFields):], fields)
-	return e
+	e.pooled = false
+	return e.clone()
 }
 
 // WithField returns a new log entry with the supplied field.
@@ -53,121 +79,223 @@ func (e Entry) WithTrace() Entry {
 
 // WithError add a minimal stack trace to the log Entry
 func (e Entry) WithError(err error) Entry {
-	return withErrFn(e.clone(), err)
+	return e.logger().withErrFn(e.clone(), err)
+}
+
+// Log logs an entry at the provided level, which may be a custom level added using RegisterLevel.
+// The exit function is not called for PanicLevel or FatalLevel.
+func (e Entry) Log(level Level, v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(level, e.callerSkip, e.recording) {
+		return
+	}
+	e.Message = fmt.Sprint(v...)
+	e.Level = level
+	e.addSource(l)
+	l.HandleEntry(e)
+}
+
+// Logf logs an entry at the provided level with formatting.
+// see Log for details.
+func (e Entry) Logf(level Level, s string, v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(level, e.callerSkip, e.recording) {
+		return
+	}
+	e.Message = fmt.Sprintf(s, v...)
+	e.Level = level
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Debug logs a debug entry
 func (e Entry) Debug(v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(DebugLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprint(v...)
 	e.Level = DebugLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Debugf logs a debug entry with formatting
 func (e Entry) Debugf(s string, v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(DebugLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprintf(s, v...)
 	e.Level = DebugLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Info logs a normal. information, entry
 func (e Entry) Info(v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(InfoLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprint(v...)
 	e.Level = InfoLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Infof logs a normal. information, entry with formatting
 func (e Entry) Infof(s string, v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(InfoLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprintf(s, v...)
 	e.Level = InfoLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Notice logs a notice log entry
 func (e Entry) Notice(v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(NoticeLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprint(v...)
 	e.Level = NoticeLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Noticef logs a notice log entry with formatting
 func (e Entry) Noticef(s string, v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(NoticeLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprintf(s, v...)
 	e.Level = NoticeLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Warn logs a warning log entry
 func (e Entry) Warn(v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(WarnLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprint(v...)
 	e.Level = WarnLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Warnf logs a warning log entry with formatting
 func (e Entry) Warnf(s string, v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(WarnLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprintf(s, v...)
 	e.Level = WarnLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Panic logs a panic log entry
 func (e Entry) Panic(v ...interface{}) {
-	e.Message = fmt.Sprint(v...)
-	e.Level = PanicLevel
-	HandleEntry(e)
-	exitFunc(1)
+	l := e.logger()
+	if l.enabledFrom(PanicLevel, e.callerSkip, e.recording) {
+		e.Message = fmt.Sprint(v...)
+		e.Level = PanicLevel
+		e.addSource(l)
+		l.HandleEntry(e)
+	}
+	l.exit(1)
 }
 
 // Panicf logs a panic log entry with formatting
 func (e Entry) Panicf(s string, v ...interface{}) {
-	e.Message = fmt.Sprintf(s, v...)
-	e.Level = PanicLevel
-	HandleEntry(e)
-	exitFunc(1)
+	l := e.logger()
+	if l.enabledFrom(PanicLevel, e.callerSkip, e.recording) {
+		e.Message = fmt.Sprintf(s, v...)
+		e.Level = PanicLevel
+		e.addSource(l)
+		l.HandleEntry(e)
+	}
+	l.exit(1)
 }
 
 // Alert logs an alert log entry
 func (e Entry) Alert(v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(AlertLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprint(v...)
 	e.Level = AlertLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Alertf logs an alert log entry with formatting
 func (e Entry) Alertf(s string, v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(AlertLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprintf(s, v...)
 	e.Level = AlertLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Fatal logs a fatal log entry
 func (e Entry) Fatal(v ...interface{}) {
-	e.Message = fmt.Sprint(v...)
-	e.Level = FatalLevel
-	HandleEntry(e)
-	exitFunc(1)
+	l := e.logger()
+	if l.enabledFrom(FatalLevel, e.callerSkip, e.recording) {
+		e.Message = fmt.Sprint(v...)
+		e.Level = FatalLevel
+		e.addSource(l)
+		l.HandleEntry(e)
+	}
+	l.exit(1)
 }
 
 // Fatalf logs a fatal log entry with formatting
 func (e Entry) Fatalf(s string, v ...interface{}) {
-	e.Message = fmt.Sprintf(s, v...)
-	e.Level = FatalLevel
-	HandleEntry(e)
-	exitFunc(1)
+	l := e.logger()
+	if l.enabledFrom(FatalLevel, e.callerSkip, e.recording) {
+		e.Message = fmt.Sprintf(s, v...)
+		e.Level = FatalLevel
+		e.addSource(l)
+		l.HandleEntry(e)
+	}
+	l.exit(1)
 }
 
 // Error logs an error log entry
 func (e Entry) Error(v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(ErrorLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprint(v...)
 	e.Level = ErrorLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
 
 // Errorf logs an error log entry with formatting
 func (e Entry) Errorf(s string, v ...interface{}) {
+	l := e.logger()
+	if !l.enabledFrom(ErrorLevel, e.callerSkip, e.recording) {
+		return
+	}
 	e.Message = fmt.Sprintf(s, v...)
 	e.Level = ErrorLevel
-	HandleEntry(e)
+	e.addSource(l)
+	l.HandleEntry(e)
 }
@@ -64,30 +64,12 @@ func errorsWithError(e Entry, err error) Entry {
 }
 
 func extractSource(b []byte, source runtimeext.Frame) []byte {
-	var funcName string
-
-	idx := strings.LastIndexByte(source.Frame.Function, '.')
-	if idx == -1 {
-		b = append(b, source.File()...)
-	} else {
-		funcName = source.Frame.Function[idx+1:]
-		remaining := source.Frame.Function[:idx]
-
-		idx = strings.LastIndexByte(remaining, '/')
-		if idx > -1 {
-			b = append(b, source.Frame.Function[:idx+1]...)
-			remaining = source.Frame.Function[idx+1:]
-		}
-
-		idx = strings.IndexByte(remaining, '.')
-		if idx == -1 {
-			b = append(b, remaining...)
-		} else {
-			b = append(b, remaining[:idx]...)
-		}
+	pkg, funcName, ok := splitFunction(source.Frame.Function)
+	if ok {
+		b = append(b, pkg...)
 		b = append(b, '/')
-		b = append(b, source.File()...)
 	}
+	b = append(b, source.File()...)
 	b = append(b, ':')
 	b = strconv.AppendInt(b, int64(source.Line()), 10)
 	if funcName != "" {
@@ -98,6 +80,27 @@ func extractSource(b []byte, source runtimeext.Frame) []byte {
 	return b
 }
 
+// splitFunction splits a fully qualified function name, as reported by runtime.Frame, into its package
+// path and function name eg. github.com/go-playground/log/v8.(*Entry).Info returns
+// github.com/go-playground/log/v8 and Info. ok is false when the name is not package qualified.
+func splitFunction(function string) (pkg, funcName string, ok bool) {
+	idx := strings.LastIndexByte(function, '.')
+	if idx == -1 {
+		return "", "", false
+	}
+	funcName = function[idx+1:]
+	remaining := function[:idx]
+
+	start := 0
+	if idx = strings.LastIndexByte(remaining, '/'); idx > -1 {
+		start = idx + 1
+	}
+	if idx = strings.IndexByte(remaining[start:], '.'); idx == -1 {
+		return remaining, funcName, true
+	}
+	return remaining[:start+idx], funcName, true
+}
+
 func formatLink(l *errors.Link, b []byte) []byte {
 	b = extractSource(b, l.Source)
 	if l.Prefix != "" {
@@ -0,0 +1,221 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// FieldKind is the type of value stored in a Field.
+type FieldKind uint8
+
+// Field kinds.
+const (
+	// KindAny is a Field created using F, or manually, whose value is stored in Value.
+	KindAny FieldKind = iota
+	KindString
+	KindInt64
+	KindBool
+	KindDuration
+	KindTime
+	KindError
+	// KindBytes is UTF-8 text stored as a byte slice.
+	KindBytes
+)
+
+// String creates a new string Field.
+func String(key, value string) Field {
+	return Field{Key: key, kind: KindString, num: uint64(len(value)), ptr: stringData(value)}
+}
+
+// Int64 creates a new int64 Field.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, kind: KindInt64, num: uint64(value)}
+}
+
+// Bool creates a new bool Field.
+func Bool(key string, value bool) Field {
+	var n uint64
+	if value {
+		n = 1
+	}
+	return Field{Key: key, kind: KindBool, num: n}
+}
+
+// Duration creates a new time.Duration Field.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, kind: KindDuration, num: uint64(value)}
+}
+
+// Time creates a new time.Time Field, any monotonic clock reading is discarded.
+func Time(key string, value time.Time) Field {
+	// times outside the range representable in nanoseconds are stored as is
+	nano := value.UnixNano()
+	if !time.Unix(0, nano).Equal(value) {
+		return Field{Key: key, Value: value, kind: KindTime}
+	}
+	return Field{Key: key, kind: KindTime, num: uint64(nano), ptr: unsafe.Pointer(value.Location())}
+}
+
+// Err creates a new error Field with the key "error".
+func Err(err error) Field {
+	return Field{Key: "error", Value: err, kind: KindError}
+}
+
+// Bytes creates a new Field from UTF-8 text held in a byte slice. The slice is copied so it may be
+// reused once the call returns, even when the entry is retained by a handler such as AsyncHandler.
+func Bytes(key string, value []byte) Field {
+	s := string(value)
+	return Field{Key: key, kind: KindBytes, num: uint64(len(s)), ptr: stringData(s)}
+}
+
+// stringHeader is the runtime representation of a string.
+type stringHeader struct {
+	data unsafe.Pointer
+	len  int
+}
+
+// stringData returns a pointer to the bytes of the string, which keeps them alive while held by a Field.
+func stringData(s string) unsafe.Pointer {
+	return (*stringHeader)(unsafe.Pointer(&s)).data
+}
+
+// text returns the string stored in a KindString or KindBytes Field.
+func (f Field) text() string {
+	if f.num == 0 {
+		return ""
+	}
+	return *(*string)(unsafe.Pointer(&stringHeader{data: f.ptr, len: int(f.num)}))
+}
+
+// Kind returns the type of value stored in the Field.
+func (f Field) Kind() FieldKind {
+	return f.kind
+}
+
+// StringValue returns the value of a KindString or KindBytes Field.
+func (f Field) StringValue() string {
+	if f.kind != KindString && f.kind != KindBytes {
+		return ""
+	}
+	return f.text()
+}
+
+// Int64Value returns the value of a KindInt64 Field.
+func (f Field) Int64Value() int64 {
+	return int64(f.num)
+}
+
+// BoolValue returns the value of a KindBool Field.
+func (f Field) BoolValue() bool {
+	return f.num == 1
+}
+
+// DurationValue returns the value of a KindDuration Field.
+func (f Field) DurationValue() time.Duration {
+	return time.Duration(f.num)
+}
+
+// TimeValue returns the value of a KindTime Field.
+func (f Field) TimeValue() time.Time {
+	if f.kind != KindTime {
+		return time.Time{}
+	}
+	if t, ok := f.Value.(time.Time); ok {
+		return t
+	}
+	return time.Unix(0, int64(f.num)).In((*time.Location)(f.ptr))
+}
+
+// ErrorValue returns the value of a KindError Field.
+func (f Field) ErrorValue() error {
+	if f.kind != KindError {
+		return nil
+	}
+	err, _ := f.Value.(error)
+	return err
+}
+
+// BytesValue returns a copy of the value of a KindBytes Field.
+func (f Field) BytesValue() []byte {
+	return []byte(f.StringValue())
+}
+
+// Interface returns the value of the Field regardless of its kind, boxing typed values.
+func (f Field) Interface() interface{} {
+	switch f.kind {
+	case KindString:
+		return f.text()
+	case KindInt64:
+		return f.Int64Value()
+	case KindBool:
+		return f.BoolValue()
+	case KindDuration:
+		return f.DurationValue()
+	case KindTime:
+		return f.TimeValue()
+	case KindError:
+		return f.ErrorValue()
+	case KindBytes:
+		return f.BytesValue()
+	}
+	return f.Value
+}
+
+// stringValue returns the value of a KindString or KindBytes Field, or a KindAny Field holding a string.
+func (f Field) stringValue() (string, bool) {
+	switch f.kind {
+	case KindString, KindBytes:
+		return f.text(), true
+	case KindAny:
+		s, ok := f.Value.(string)
+		return s, ok
+	}
+	return "", false
+}
+
+// plainField is a Field's key and resolved value, used to format and encode it as before typed values.
+type plainField struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// Format implements fmt.Formatter, formatting the Field as a struct of its key and value regardless of
+// its kind.
+func (f Field) Format(s fmt.State, verb rune) {
+	format := make([]byte, 0, 16)
+	format = append(format, '%')
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			format = append(format, byte(flag))
+		}
+	}
+	if width, ok := s.Width(); ok {
+		format = strconv.AppendInt(format, int64(width), 10)
+	}
+	if precision, ok := s.Precision(); ok {
+		format = append(format, '.')
+		format = strconv.AppendInt(format, int64(precision), 10)
+	}
+	format = append(format, string(verb)...)
+	fmt.Fprintf(s, string(format), plainField{Key: f.Key, Value: f.Interface()})
+}
+
+// MarshalJSON encodes the Field as its key and value, regardless of its kind. Errors and bytes are encoded
+// as strings.
+func (f Field) MarshalJSON() ([]byte, error) {
+	var value interface{}
+	switch f.kind {
+	case KindBytes:
+		value = f.text()
+	case KindError:
+		if err := f.ErrorValue(); err != nil {
+			value = err.Error()
+		}
+	default:
+		value = f.Interface()
+	}
+	return json.Marshal(plainField{Key: f.Key, Value: value})
+}
@@ -0,0 +1,145 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestTypedFields(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	err := errors.New("failed")
+
+	tests := []struct {
+		field    Field
+		kind     FieldKind
+		expected interface{}
+	}{
+		{field: String("k", "v"), kind: KindString, expected: "v"},
+		{field: String("k", ""), kind: KindString, expected: ""},
+		{field: Int64("k", -42), kind: KindInt64, expected: int64(-42)},
+		{field: Bool("k", true), kind: KindBool, expected: true},
+		{field: Bool("k", false), kind: KindBool, expected: false},
+		{field: Duration("k", time.Second), kind: KindDuration, expected: time.Second},
+		{field: Time("k", now), kind: KindTime, expected: now},
+		{field: Time("k", time.Time{}), kind: KindTime, expected: time.Time{}},
+		{field: Err(err), kind: KindError, expected: err},
+		{field: F("k", "v"), kind: KindAny, expected: "v"},
+	}
+
+	for i, tt := range tests {
+		if tt.field.Kind() != tt.kind {
+			t.Errorf("Index: %d Expected kind %d Got %d", i, tt.kind, tt.field.Kind())
+		}
+		if tt.field.Interface() != tt.expected {
+			t.Errorf("Index: %d Expected '%v' Got '%v'", i, tt.expected, tt.field.Interface())
+		}
+	}
+
+	local := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("test", 3600))
+	if tm := Time("k", local).TimeValue(); !tm.Equal(local) || tm.Location() != local.Location() {
+		t.Errorf("Expected '%v' Got '%v'", local, tm)
+	}
+
+	b := Bytes("k", []byte("text"))
+	if b.Kind() != KindBytes || b.StringValue() != "text" || string(b.BytesValue()) != "text" {
+		t.Errorf("Expected bytes field with 'text' Got '%v'", b)
+	}
+	if Err(err).Key != "error" {
+		t.Errorf("Expected key 'error' Got '%s'", Err(err).Key)
+	}
+
+	// accessors of other kinds return the zero value rather than misreading the stored value
+	if Time("k", now).StringValue() != "" || String("k", "v").ErrorValue() != nil || !String("k", "v").TimeValue().IsZero() {
+		t.Error("Expected zero values from accessors of other kinds")
+	}
+
+	// every field is copied with each entry so must stay small
+	if size := unsafe.Sizeof(Field{}); size > 56 {
+		t.Errorf("Expected Field of at most 56 bytes Got %d", size)
+	}
+}
+
+func TestTypedFieldsNoAlloc(t *testing.T) {
+	err := errors.New("failed")
+	now := time.Now()
+	var fields [6]Field
+
+	allocs := testing.AllocsPerRun(100, func() {
+		fields[0] = String("string", "value")
+		fields[1] = Int64("int64", 1)
+		fields[2] = Bool("bool", true)
+		fields[3] = Duration("duration", time.Second)
+		fields[4] = Time("time", now)
+		fields[5] = Err(err)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected 0 allocations Got %v", allocs)
+	}
+}
+
+func TestTypedFieldsConsole(t *testing.T) {
+	buff := new(bytes.Buffer)
+	c := NewConsoleBuilder().WithWriter(buff).WithTimestampFormat("2006").Build()
+	c.Log(Entry{
+		Message:   "typed",
+		Level:     InfoLevel,
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Fields: []Field{
+			String("string", "value"),
+			Int64("int64", -1),
+			Bool("bool", true),
+			Duration("duration", 1500*time.Millisecond),
+			Time("time", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Err(errors.New("failed")),
+			Bytes("bytes", []byte("text")),
+			G("group", String("nested", "n")),
+		},
+	})
+	expected := "2024   INFO typed string=value int64=-1 bool=true duration=1.5s time=2023 error=failed bytes=text group.nested=n\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+func TestTypedFieldsJSON(t *testing.T) {
+	b, err := json.Marshal([]Field{
+		String("string", "value"),
+		Int64("int64", 2),
+		Err(errors.New("failed")),
+		Bytes("bytes", []byte("text")),
+		G("group", Bool("bool", false)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"key":"string","value":"value"},{"key":"int64","value":2},{"key":"error","value":"failed"},` +
+		`{"key":"bytes","value":"text"},{"key":"group","value":[{"key":"bool","value":false}]}]`
+	if string(b) != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, string(b))
+	}
+}
+
+func TestTypedFieldsFormat(t *testing.T) {
+	if s := fmt.Sprintf("%v", []Field{String("k", "v"), F("a", 1)}); s != "[{k v} {a 1}]" {
+		t.Errorf("Expected '%s' Got '%s'", "[{k v} {a 1}]", s)
+	}
+	if s := fmt.Sprintf("%+v", Int64("k", 1)); s != "{Key:k Value:1}" {
+		t.Errorf("Expected '%s' Got '%s'", "{Key:k Value:1}", s)
+	}
+}
+
+func TestTypedFieldsRedaction(t *testing.T) {
+	r := NewRedactorBuilder().WithValuePatterns(RedactMask, regexp.MustCompile(`\d{4}`)).Build()
+	e := r.Redact(Entry{Fields: []Field{String("card", "card 1234"), Int64("count", 1234)}})
+	assertFields(t, "", e.Fields, []Field{F("card", "card [REDACTED]"), Int64("count", 1234)})
+
+	p := NewPIIScannerBuilder().Build()
+	e = p.Scan(Entry{Fields: []Field{Bytes("email", []byte("joe@example.com"))}})
+	assertFields(t, "", e.Fields, []Field{F("email", "[REDACTED]")})
+}
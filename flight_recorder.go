@@ -0,0 +1,146 @@
+package log
+
+import (
+	"context"
+	"sync"
+)
+
+type flightIdent struct{}
+
+// FlightRecorderBuilder is used to configure and create a new FlightRecorder
+type FlightRecorderBuilder struct {
+	size    int
+	levels  []Level
+	trigger Level
+}
+
+// NewFlightRecorderBuilder creates a new FlightRecorderBuilder that buffers the last 256 Debug and Info
+// entries of a context until an Error or higher entry is logged.
+func NewFlightRecorderBuilder() *FlightRecorderBuilder {
+	return &FlightRecorderBuilder{
+		size:    256,
+		levels:  []Level{DebugLevel, InfoLevel},
+		trigger: ErrorLevel,
+	}
+}
+
+// WithSize sets the maximum number of entries buffered per context, once full the oldest are discarded.
+func (b *FlightRecorderBuilder) WithSize(size int) *FlightRecorderBuilder {
+	b.size = size
+	return b
+}
+
+// WithLevels sets the levels that are buffered.
+func (b *FlightRecorderBuilder) WithLevels(levels ...Level) *FlightRecorderBuilder {
+	b.levels = append([]Level(nil), levels...)
+	return b
+}
+
+// WithTriggerLevel sets the level, by severity, at or above which buffered entries are emitted.
+func (b *FlightRecorderBuilder) WithTriggerLevel(level Level) *FlightRecorderBuilder {
+	b.trigger = level
+	return b
+}
+
+// Build creates a new FlightRecorder.
+func (b *FlightRecorderBuilder) Build() *FlightRecorder {
+	r := &FlightRecorder{
+		size:    b.size,
+		trigger: b.trigger,
+	}
+	for _, level := range b.levels {
+		r.levels.add(level)
+	}
+	return r
+}
+
+// FlightRecorder buffers low level entries logged using a context's entry, as returned by GetContext or
+// Ctx, and only passes them to the handlers if an entry at or above the trigger level is logged for the
+// same context. Once triggered, entries of the context are no longer buffered.
+//
+// Entries at the buffered levels are recorded even when no handler is registered for them and are passed
+// to the handlers of the triggering entry's level, along with those logged for the context afterwards.
+// So Debug entries can be recorded for every request while only handlers for Info and above are
+// registered, without Debug entries logged outside a recorded context being emitted.
+//
+// A FlightRecorder holds no state itself and can be shared, each call to Start begins a new recording.
+type FlightRecorder struct {
+	size    int
+	levels  levelSet
+	trigger Level
+}
+
+// Start returns a copy of the context recording the entries logged using it. The returned function must
+// be called when the request ends, discarding any entries still buffered; buffered level entries logged
+// after it is called are also discarded.
+func (r *FlightRecorder) Start(ctx context.Context) (context.Context, func()) {
+	rec := &flightRecording{
+		recorder: r,
+	}
+	return context.WithValue(ctx, flightIdent{}, rec), rec.end
+}
+
+func flightRecordingFromContext(ctx context.Context) *flightRecording {
+	rec, _ := ctx.Value(flightIdent{}).(*flightRecording)
+	return rec
+}
+
+// flightRecording is the ring buffer of a single context. The buffer grows as entries are recorded and
+// once full the oldest, at head, is overwritten.
+type flightRecording struct {
+	m         sync.Mutex
+	recorder  *FlightRecorder
+	buffer    []Entry
+	head      int
+	triggered bool
+	// level is the level of the entry that triggered the recording.
+	level Level
+	ended bool
+}
+
+// record buffers the entry, returning false, if it is at a buffered level. When the entry is at or above
+// the trigger level the previously buffered entries, in the order they were logged, are returned. Entries
+// at buffered levels that are replayed or logged once triggered are marked to be passed to the handlers of
+// the triggering entry's level.
+func (r *flightRecording) record(e Entry) (replay []Entry, emitted Entry, emit bool) {
+	buffered := r.recorder.levels.has(e.Level)
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	switch {
+	case r.triggered:
+		if buffered {
+			e.replayed, e.triggerLevel = true, r.level
+		}
+		return nil, e, true
+	case r.ended:
+		return nil, e, !buffered
+	case buffered:
+		if len(r.buffer) < r.recorder.size {
+			r.buffer = append(r.buffer, e.retain())
+		} else if len(r.buffer) > 0 {
+			r.buffer[r.head] = e.retain()
+			r.head = (r.head + 1) % len(r.buffer)
+		}
+		return nil, e, false
+	case e.Level.Severity() >= r.recorder.trigger.Severity():
+		r.triggered = true
+		r.level = e.Level
+		replay = append(r.buffer[r.head:len(r.buffer):len(r.buffer)], r.buffer[:r.head]...)
+		r.buffer = nil
+		for i := range replay {
+			replay[i].replayed, replay[i].triggerLevel = true, e.Level
+		}
+		return replay, e, true
+	}
+	return nil, e, true
+}
+
+// end discards any buffered entries.
+func (r *flightRecording) end() {
+	r.m.Lock()
+	r.ended = true
+	r.buffer = nil
+	r.m.Unlock()
+}
@@ -0,0 +1,103 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(&testHandler{writer: buff}, AllLevels...)
+
+	rec := NewFlightRecorderBuilder().WithSize(2).Build()
+
+	// discarded when the request ends without an error
+	ctx, end := rec.Start(context.Background())
+	l.Ctx(ctx).Debug("debug")
+	l.Ctx(ctx).Warn("warn")
+	end()
+	l.Ctx(ctx).Info("after end")
+	if buff.String() != "WARN warn\n" {
+		t.Errorf("Expected '%s' Got '%s'", "WARN warn\n", buff.String())
+	}
+
+	// emitted, keeping only the most recent, when an error occurs
+	buff.Reset()
+	ctx, end = rec.Start(context.Background())
+	defer end()
+	other, endOther := rec.Start(context.Background())
+	defer endOther()
+
+	e := l.Ctx(ctx).WithField("request_id", "1")
+	e.Debug("one")
+	e.Info("two")
+	e.Debug("three")
+	l.Ctx(other).Info("other")
+	l.Info("unrecorded")
+	e.Error("failed")
+	e.Debug("four")
+	l.Ctx(other).Error("other failed")
+
+	expected := "INFO unrecorded\n" +
+		"INFO two request_id=1\n" +
+		"DEBUG three request_id=1\n" +
+		"ERROR failed request_id=1\n" +
+		"DEBUG four request_id=1\n" +
+		"INFO other\n" +
+		"ERROR other failed\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+func TestFlightRecorderLazyBuffer(t *testing.T) {
+	l := New()
+	l.AddHandler(&testHandler{writer: new(bytes.Buffer)}, AllLevels...)
+
+	ctx, end := NewFlightRecorderBuilder().WithSize(4).Build().Start(context.Background())
+	defer end()
+	rec := flightRecordingFromContext(ctx)
+	if cap(rec.buffer) != 0 {
+		t.Fatalf("Expected no buffer before recording Got capacity %d", cap(rec.buffer))
+	}
+	for i := 0; i < 10; i++ {
+		l.Ctx(ctx).Debug("debug")
+	}
+	if len(rec.buffer) != 4 {
+		t.Errorf("Expected buffer of %d entries Got %d", 4, len(rec.buffer))
+	}
+}
+
+func TestFlightRecorderWithoutDebugHandlers(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(&testHandler{writer: buff}, LevelsAtLeast(InfoLevel)...)
+	errBuff := new(bytes.Buffer)
+	l.AddHandler(&testHandler{writer: errBuff}, ErrorLevel)
+
+	rec := NewFlightRecorderBuilder().Build()
+	ctx, end := rec.Start(context.Background())
+	defer end()
+
+	l.Debug("unrecorded")
+	l.Ctx(context.Background()).Debug("unrecorded context")
+	l.Ctx(ctx).Debug("one")
+	l.Ctx(ctx).Info("two")
+	if buff.Len() != 0 || errBuff.Len() != 0 {
+		t.Fatalf("Expected nothing emitted before the trigger Got '%s' '%s'", buff.String(), errBuff.String())
+	}
+
+	// replayed, and once triggered logged, to the handlers of the triggering entry's level
+	l.Ctx(ctx).Error("failed")
+	l.Ctx(ctx).Debug("three")
+	l.Debug("unrecorded")
+	expected := "DEBUG one\nINFO two\nERROR failed\nDEBUG three\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+	if errBuff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, errBuff.String())
+	}
+}
@@ -0,0 +1,85 @@
+package log
+
+import "sync/atomic"
+
+// LevelVar is the minimum level of a handler registered using AddHandlerAtLeast, it can safely be
+// changed at runtime while logging.
+type LevelVar struct {
+	level uint32
+	l     *Instance
+	h     Handler
+}
+
+// Level returns the current minimum level.
+func (v *LevelVar) Level() Level {
+	return Level(atomic.LoadUint32(&v.level))
+}
+
+// Set changes the minimum level, registering the handler for all registered levels at least as severe.
+// It has no effect once the handler has been removed or its levels set using SetHandlerLevels, nor for
+// handlers whose dynamic type is not comparable as they cannot be identified.
+func (v *LevelVar) Set(level Level) {
+	v.l.update(func(s *snapshot) {
+		if s.minLevelFor(v.h) != v {
+			return
+		}
+		atomic.StoreUint32(&v.level, uint32(level))
+		s.setHandlerLevels(v.h, LevelsAtLeast(level)...)
+	})
+}
+
+// String returns the name of the current minimum level.
+func (v *LevelVar) String() string {
+	return v.Level().String()
+}
+
+// AddHandlerAtLeast adds a handler to the Default Instance for all levels at least as severe as min.
+// see Instance.AddHandlerAtLeast for details.
+func AddHandlerAtLeast(h Handler, min Level) *LevelVar {
+	return Default().AddHandlerAtLeast(h, min)
+}
+
+// SetHandlerLevels sets the levels of a handler of the Default Instance.
+// see Instance.SetHandlerLevels for details.
+func SetHandlerLevels(h Handler, levels ...Level) {
+	Default().SetHandlerLevels(h, levels...)
+}
+
+// HandlerLevels returns the levels a handler of the Default Instance is registered for.
+// see Instance.HandlerLevels for details.
+func HandlerLevels(h Handler) []Level {
+	return Default().HandlerLevels(h)
+}
+
+// AddHandlerAtLeast adds a handler for all registered levels at least as severe as min, returning the
+// LevelVar used to change the minimum level at runtime.
+func (l *Instance) AddHandlerAtLeast(h Handler, min Level) *LevelVar {
+	v := &LevelVar{level: uint32(min), l: l, h: h}
+	l.update(func(s *snapshot) {
+		if l.defaultHandler != nil {
+			s.removeHandler(l.defaultHandler)
+			l.defaultHandler = nil
+		}
+		s.removeMinLevel(h)
+		if sameHandler(h, h) {
+			s.minLevels = append(s.minLevels, v)
+		}
+		s.setHandlerLevels(h, LevelsAtLeast(min)...)
+	})
+	return v
+}
+
+// SetHandlerLevels atomically replaces the levels the handler is registered for, registering it if it
+// is not already, without affecting its middleware. Passing no levels stops the handler receiving
+// entries. Any LevelVar of the handler no longer applies.
+func (l *Instance) SetHandlerLevels(h Handler, levels ...Level) {
+	l.update(func(s *snapshot) {
+		s.removeMinLevel(h)
+		s.setHandlerLevels(h, levels...)
+	})
+}
+
+// HandlerLevels returns the levels the handler is registered for, ordered by severity.
+func (l *Instance) HandlerLevels(h Handler) []Level {
+	return l.load().handlerLevels(h)
+}
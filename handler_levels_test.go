@@ -0,0 +1,121 @@
+package log
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func assertLevels(t *testing.T, got, expected []Level) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected '%v' Got '%v'", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected '%v' Got '%v'", expected, got)
+		}
+	}
+}
+
+func TestAddHandlerAtLeast(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	h := &testHandler{writer: buff}
+	v := l.AddHandlerAtLeast(h, WarnLevel)
+
+	assertLevels(t, l.HandlerLevels(h), []Level{WarnLevel, ErrorLevel, PanicLevel, AlertLevel, FatalLevel})
+	if l.Enabled(InfoLevel) {
+		t.Errorf("Expected info to be disabled")
+	}
+	l.Info("info")
+	l.Warn("warn")
+
+	v.Set(InfoLevel)
+	if v.Level() != InfoLevel || v.String() != "INFO" {
+		t.Errorf("Expected '%s' Got '%s'", InfoLevel, v.Level())
+	}
+	l.Debug("debug")
+	l.Info("info")
+
+	expected := "WARN warn\nINFO info\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	// explicitly set levels replace the minimum level
+	l.SetHandlerLevels(h, DebugLevel)
+	v.Set(ErrorLevel)
+	assertLevels(t, l.HandlerLevels(h), []Level{DebugLevel})
+
+	l.SetHandlerLevels(h)
+	assertLevels(t, l.HandlerLevels(h), nil)
+	if l.Enabled(DebugLevel) {
+		t.Errorf("Expected debug to be disabled")
+	}
+}
+
+func TestAddHandlerAtLeastCustomLevels(t *testing.T) {
+	registerExtendedLevels(t)
+
+	l := New()
+	h := &testHandler{writer: new(bytes.Buffer)}
+	l.AddHandlerAtLeast(h, ErrorLevel)
+	assertLevels(t, l.HandlerLevels(h), []Level{ErrorLevel, SecurityLevel, PanicLevel, AlertLevel, FatalLevel})
+}
+
+func TestSetHandlerLevelsKeepsMiddleware(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	h := &testHandler{writer: buff}
+	l.AddHandler(h, InfoLevel)
+	l.UseFor(h, func(e Entry) (Entry, bool) {
+		return e.WithField("mw", true), true
+	})
+
+	l.SetHandlerLevels(h, InfoLevel, WarnLevel)
+	l.Warn("warn")
+	if buff.String() != "WARN warn mw=true\n" {
+		t.Errorf("Expected '%s' Got '%s'", "WARN warn mw=true\n", buff.String())
+	}
+}
+
+func TestLevelVarConcurrent(t *testing.T) {
+	l := New()
+	v := l.AddHandlerAtLeast(nopHandler{}, InfoLevel)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			v.Set(AllLevels[i%len(AllLevels)])
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			l.Error("error")
+			_ = l.HandlerLevels(nopHandler{})
+		}
+	}()
+	wg.Wait()
+}
+
+func TestAddHandlerAtLeastNonComparable(t *testing.T) {
+	l := New()
+	var entries []string
+	h := valueHandler{entries: &entries}
+	v := l.AddHandlerAtLeast(h, WarnLevel)
+	l.AddHandlerAtLeast(&testHandler{writer: new(bytes.Buffer)}, InfoLevel)
+
+	// the handler cannot be identified so these have no effect, but must not panic
+	v.Set(DebugLevel)
+	l.RemoveHandler(h)
+	l.Info("info")
+	l.Warn("warn")
+	if len(entries) != 1 || entries[0] != "warn" {
+		t.Errorf("Expected '[warn]' Got '%v'", entries)
+	}
+	assertLevels(t, l.HandlerLevels(h), nil)
+}
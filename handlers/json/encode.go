@@ -0,0 +1,194 @@
+package json
+
+import (
+	stdjson "encoding/json"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	log "github.com/go-playground/log/v8"
+)
+
+const hex = "0123456789abcdef"
+
+// appendEntry appends the entry in the same form as encoding/json.
+func appendEntry(b []byte, e log.Entry) ([]byte, error) {
+	b = append(b, `{"message":`...)
+	b = appendString(b, e.Message)
+	b = append(b, `,"timestamp":"`...)
+	b = e.Timestamp.AppendFormat(b, time.RFC3339Nano)
+	b = append(b, `","fields":`...)
+	var err error
+	if b, err = appendFields(b, e.Fields); err != nil {
+		return b, err
+	}
+	b = append(b, `,"level":`...)
+	b = appendString(b, e.Level.String())
+	if e.Caller != "" {
+		b = append(b, `,"caller":`...)
+		b = appendString(b, e.Caller)
+	}
+	return append(b, '}'), nil
+}
+
+func appendFields(b []byte, fields []log.Field) ([]byte, error) {
+	if fields == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '[')
+	for i, f := range fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, `{"key":`...)
+		b = appendString(b, f.Key)
+		b = append(b, `,"value":`...)
+		var err error
+		if b, err = appendValue(b, f); err != nil {
+			return b, err
+		}
+		b = append(b, '}')
+	}
+	return append(b, ']'), nil
+}
+
+func appendValue(b []byte, f log.Field) ([]byte, error) {
+	switch f.Kind() {
+	case log.KindString, log.KindBytes:
+		return appendString(b, f.StringValue()), nil
+	case log.KindInt64:
+		return strconv.AppendInt(b, f.Int64Value(), 10), nil
+	case log.KindDuration:
+		return strconv.AppendInt(b, int64(f.DurationValue()), 10), nil
+	case log.KindBool:
+		return strconv.AppendBool(b, f.BoolValue()), nil
+	case log.KindTime:
+		b = append(b, '"')
+		b = f.TimeValue().AppendFormat(b, time.RFC3339Nano)
+		return append(b, '"'), nil
+	case log.KindError:
+		if err := f.ErrorValue(); err != nil {
+			return appendString(b, err.Error()), nil
+		}
+		return append(b, "null"...), nil
+	}
+
+	switch t := f.Value.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case string:
+		return appendString(b, t), nil
+	case bool:
+		return strconv.AppendBool(b, t), nil
+	case int:
+		return strconv.AppendInt(b, int64(t), 10), nil
+	case int8:
+		return strconv.AppendInt(b, int64(t), 10), nil
+	case int16:
+		return strconv.AppendInt(b, int64(t), 10), nil
+	case int32:
+		return strconv.AppendInt(b, int64(t), 10), nil
+	case int64:
+		return strconv.AppendInt(b, t, 10), nil
+	case uint:
+		return strconv.AppendUint(b, uint64(t), 10), nil
+	case uint8:
+		return strconv.AppendUint(b, uint64(t), 10), nil
+	case uint16:
+		return strconv.AppendUint(b, uint64(t), 10), nil
+	case uint32:
+		return strconv.AppendUint(b, uint64(t), 10), nil
+	case uint64:
+		return strconv.AppendUint(b, t, 10), nil
+	case float32:
+		if !math.IsInf(float64(t), 0) && !math.IsNaN(float64(t)) {
+			return appendFloat(b, float64(t), 32), nil
+		}
+	case float64:
+		if !math.IsInf(t, 0) && !math.IsNaN(t) {
+			return appendFloat(b, t, 64), nil
+		}
+	case []log.Field:
+		return appendFields(b, t)
+	}
+
+	raw, err := stdjson.Marshal(f.Value)
+	if err != nil {
+		return b, err
+	}
+	return append(b, raw...), nil
+}
+
+// appendFloat formats the float as encoding/json does.
+func appendFloat(b []byte, f float64, bits int) []byte {
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+// appendString appends s as a quoted JSON string, escaped as encoding/json does including HTML characters.
+func appendString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
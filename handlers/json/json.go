@@ -5,6 +5,7 @@ import (
 	stdjson "encoding/json"
 	"io"
 	"sync"
+	"sync/atomic"
 
 	log "github.com/go-playground/log/v8"
 )
@@ -13,18 +14,65 @@ import (
 type Handler struct {
 	m sync.Mutex
 	*stdjson.Encoder
+	writer io.Writer
+	// encoder is the Encoder created by New, entries are encoded using Encoder instead once it has been
+	// configured or replaced.
+	encoder    *stdjson.Encoder
+	configured uint32
 }
 
 // New handler.
 func New(w io.Writer) *Handler {
+	enc := stdjson.NewEncoder(w)
 	return &Handler{
-		Encoder: stdjson.NewEncoder(w),
+		Encoder: enc,
+		writer:  w,
+		encoder: enc,
 	}
 }
 
-// Log handles the log entry
-func (h *Handler) Log(e log.Entry) {
+// SetIndent configures the Encoder to indent entries, see encoding/json.Encoder.SetIndent.
+func (h *Handler) SetIndent(prefix, indent string) {
+	h.m.Lock()
+	h.Encoder.SetIndent(prefix, indent)
+	atomic.StoreUint32(&h.configured, 1)
+	h.m.Unlock()
+}
+
+// SetEscapeHTML configures whether the Encoder escapes HTML characters, see
+// encoding/json.Encoder.SetEscapeHTML.
+func (h *Handler) SetEscapeHTML(on bool) {
 	h.m.Lock()
-	_ = h.Encoder.Encode(e)
+	h.Encoder.SetEscapeHTML(on)
+	atomic.StoreUint32(&h.configured, 1)
 	h.m.Unlock()
 }
+
+// Log handles the log entry, encoding it as encoding/json would without reflection for all but custom
+// field value types. Once the Encoder has been configured using SetIndent or SetEscapeHTML, or replaced,
+// entries are encoded using it instead.
+func (h *Handler) Log(e log.Entry) {
+	if atomic.LoadUint32(&h.configured) == 1 || h.Encoder != h.encoder {
+		h.m.Lock()
+		err := h.Encoder.Encode(e)
+		h.m.Unlock()
+		if err != nil {
+			log.ReportError(h, e, err)
+		}
+		return
+	}
+
+	buff := log.BytePool().Get()
+	var err error
+	buff.B, err = appendEntry(buff.B, e)
+	if err == nil {
+		buff.B = append(buff.B, '\n')
+		h.m.Lock()
+		_, err = h.writer.Write(buff.B)
+		h.m.Unlock()
+	}
+	log.BytePool().Put(buff)
+	if err != nil {
+		log.ReportError(h, e, err)
+	}
+}
@@ -2,8 +2,12 @@ package json
 
 import (
 	"bytes"
+	stdjson "encoding/json"
+	"errors"
+	"io"
 	"strings"
 	"testing"
+	"time"
 
 	log "github.com/go-playground/log/v8"
 )
@@ -18,3 +22,139 @@ func TestJSONLogger(t *testing.T) {
 		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
 	}
 }
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("closed pipe")
+}
+
+func TestJSONLoggerReportsErrors(t *testing.T) {
+	l := log.New()
+	h := New(failingWriter{})
+	l.AddHandler(h, log.AllLevels...)
+
+	var reported error
+	l.SetErrorFunc(func(_ log.Handler, _ log.Entry, err error) {
+		reported = err
+	})
+	l.Info("info")
+	if reported == nil || reported.Error() != "closed pipe" {
+		t.Errorf("Expected '%s' Got '%v'", "closed pipe", reported)
+	}
+}
+
+func TestJSONLoggerCaller(t *testing.T) {
+	var buff bytes.Buffer
+	l := log.New()
+	l.AddHandler(New(&buff), log.AllLevels...)
+	l.WithCaller().Info("info")
+	expected := `"level":"INFO","caller":"github.com/go-playground/log/v8/handlers/json/json_test.go:51:TestJSONLoggerCaller"}`
+	if !strings.HasSuffix(strings.TrimSpace(buff.String()), expected) {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+type jane struct {
+	Name string `json:"name"`
+}
+
+func TestJSONLoggerMatchesEncodingJSON(t *testing.T) {
+	e := log.Entry{
+		Message:   "<html> & \"quotes\" \n\t\x01   \xff",
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 600, time.FixedZone("test", 3600)),
+		Level:     log.WarnLevel,
+		Caller:    "pkg/file.go:1:fn",
+		Fields: []log.Field{
+			log.F("string", "value"),
+			log.F("int", -1),
+			log.F("uint8", uint8(2)),
+			log.F("float", 3.5),
+			log.F("small", 1e-7),
+			log.F("large", float32(1e21)),
+			log.F("bool", true),
+			log.F("nil", nil),
+			log.F("struct", jane{Name: "Jane"}),
+			log.F("time", time.Unix(0, 0).UTC()),
+			log.F("duration", time.Second),
+			log.G("group", log.F("nested", "n"), log.String("typed", "t")),
+			log.String("typed string", "value"),
+			log.Int64("typed int", 42),
+			log.Bool("typed bool", false),
+			log.Duration("typed duration", time.Minute),
+			log.Time("typed time", time.Unix(1, 5).UTC()),
+			log.Bytes("typed bytes", []byte("bytes")),
+			log.Err(errors.New("failed")),
+		},
+	}
+
+	expected, err := stdjson.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buff bytes.Buffer
+	New(&buff).Log(e)
+	if buff.String() != string(expected)+"\n" {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	e.Fields = nil
+	expected, _ = stdjson.Marshal(e)
+	buff.Reset()
+	New(&buff).Log(e)
+	if buff.String() != string(expected)+"\n" {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+var raceEnabled bool
+
+func TestJSONLoggerPooledNoAlloc(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not stable with the race detector enabled")
+	}
+	l := log.New()
+	l.AddHandler(New(io.Discard), log.AllLevels...)
+	err := errors.New("failed")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		l.Acquire().With(
+			log.String("string", "value"),
+			log.Int64("int64", 1),
+			log.Bool("bool", true),
+			log.Err(err),
+		).Info("pooled")
+	})
+	if allocs != 0 {
+		t.Errorf("Expected 0 allocations Got %v", allocs)
+	}
+}
+
+func TestJSONLoggerEncoderOptions(t *testing.T) {
+	var buff bytes.Buffer
+	h := New(&buff)
+	h.SetIndent("", "  ")
+	h.SetEscapeHTML(false)
+
+	e := log.Entry{Message: "<b>", Level: log.InfoLevel, Fields: []log.Field{log.F("key", "value")}}
+	h.Log(e)
+
+	var expected bytes.Buffer
+	enc := stdjson.NewEncoder(&expected)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(e); err != nil {
+		t.Fatal(err)
+	}
+	if buff.String() != expected.String() {
+		t.Errorf("Expected '%s' Got '%s'", expected.String(), buff.String())
+	}
+
+	// replacing the Encoder uses it as before
+	var replaced bytes.Buffer
+	h.Encoder = stdjson.NewEncoder(&replaced)
+	h.Log(e)
+	if !strings.HasPrefix(replaced.String(), `{"message":"\u003cb\u003e"`) {
+		t.Errorf("Expected entry encoded by the replaced Encoder Got '%s'", replaced.String())
+	}
+}
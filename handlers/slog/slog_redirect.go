@@ -25,14 +25,36 @@ func New(handler slog.Handler) *Handler {
 
 // Log handles the log entry
 func (h *Handler) Log(e log.Entry) {
-	r := slog.NewRecord(e.Timestamp, slog.Level(e.Level), e.Message, 0)
+	r := slog.NewRecord(e.Timestamp, e.Level.SlogLevel(), e.Message, 0)
 	r.AddAttrs(h.convertFields(e.Fields)...)
-	_ = h.handler.Handle(context.Background(), r)
+	if err := h.handler.Handle(context.Background(), r); err != nil {
+		log.ReportError(h, e, err)
+	}
 }
 
 func (h *Handler) convertFields(fields []log.Field) []slog.Attr {
 	attrs := make([]slog.Attr, 0, len(fields))
 	for _, f := range fields {
+		switch f.Kind() {
+		case log.KindString, log.KindBytes:
+			attrs = append(attrs, slog.String(f.Key, f.StringValue()))
+			continue
+		case log.KindInt64:
+			attrs = append(attrs, slog.Int64(f.Key, f.Int64Value()))
+			continue
+		case log.KindBool:
+			attrs = append(attrs, slog.Bool(f.Key, f.BoolValue()))
+			continue
+		case log.KindDuration:
+			attrs = append(attrs, slog.Duration(f.Key, f.DurationValue()))
+			continue
+		case log.KindTime:
+			attrs = append(attrs, slog.Time(f.Key, f.TimeValue()))
+			continue
+		case log.KindError:
+			attrs = append(attrs, slog.Any(f.Key, f.ErrorValue()))
+			continue
+		}
 		switch t := f.Value.(type) {
 		case []log.Field:
 			a := h.convertFields(t)
@@ -52,7 +74,7 @@ func (h *Handler) convertFields(fields []log.Field) []slog.Attr {
 // This function replaces the "level" attribute to get the custom log levels of this package.
 var ReplaceAttrFn = func(groups []string, a slog.Attr) slog.Attr {
 	if a.Key == slog.LevelKey {
-		level := log.Level(a.Value.Any().(slog.Level))
+		level := log.LevelFromSlog(a.Value.Any().(slog.Level))
 		a.Value = slog.StringValue(level.String())
 	}
 	return a
@@ -0,0 +1,70 @@
+package log
+
+import "fmt"
+
+// Namer is an optional interface a Handler can implement to provide a descriptive name to Handlers.
+type Namer interface {
+	Name() string
+}
+
+// StatsReporter is an optional interface a Handler can implement to report counters, such as the
+// number of entries written or dropped, to Handlers.
+type StatsReporter interface {
+	Stats() map[string]uint64
+}
+
+// HandlerInfo describes a registered handler.
+type HandlerInfo struct {
+	Handler Handler
+	// Name is the handler's Name when it implements Namer, otherwise its Type.
+	Name string
+	// Type is the Go type of the handler eg. *json.Handler.
+	Type string
+	// Levels the handler is registered for, ordered by severity.
+	Levels []Level
+	// MinLevel is the minimum level of a handler registered using AddHandlerAtLeast, otherwise nil.
+	MinLevel *LevelVar
+	// Middleware is the number of middleware registered for the handler using UseFor.
+	Middleware int
+	// Stats are the handler's counters when it implements StatsReporter, otherwise nil.
+	Stats map[string]uint64
+}
+
+// Handlers returns the handlers registered with the Default Instance.
+// see Instance.Handlers for details.
+func Handlers() []HandlerInfo {
+	return Default().Handlers()
+}
+
+// Handlers returns a point in time description of each registered handler, ordered by the least severe
+// level they are registered for and then registration order. Handlers whose dynamic type is not comparable cannot be
+// identified and so are described once for each level they are registered for.
+func (l *Instance) Handlers() []HandlerInfo {
+	s := l.load()
+	handlers := s.registeredHandlers()
+	infos := make([]HandlerInfo, 0, len(handlers))
+	for _, rh := range handlers {
+		h := rh.h
+		info := HandlerInfo{
+			Handler:    h,
+			Type:       fmt.Sprintf("%T", h),
+			Levels:     rh.levels,
+			MinLevel:   s.minLevelFor(h),
+			Middleware: len(s.middlewareFor(h)),
+		}
+		info.Name = handlerName(h)
+		if r, ok := h.(StatsReporter); ok {
+			info.Stats = r.Stats()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// handlerName returns the handler's Name when it implements Namer, otherwise its type.
+func handlerName(h Handler) string {
+	if n, ok := h.(Namer); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", h)
+}
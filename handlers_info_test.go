@@ -0,0 +1,70 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+type namedHandler struct {
+	testHandler
+}
+
+func (h *namedHandler) Name() string {
+	return "named"
+}
+
+func TestHandlers(t *testing.T) {
+	l := New()
+	console := NewConsoleBuilder().WithWriter(new(bytes.Buffer)).Build()
+	named := &namedHandler{testHandler{writer: new(bytes.Buffer)}}
+	async := NewAsyncBuilder().Build(named)
+	defer async.Close()
+
+	l.AddHandler(console, ErrorLevel, DebugLevel)
+	v := l.AddHandlerAtLeast(async, WarnLevel)
+	l.UseFor(async, func(e Entry) (Entry, bool) { return e, true })
+
+	infos := l.Handlers()
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 handlers Got %d", len(infos))
+	}
+
+	info := infos[0]
+	if info.Handler != console || info.Name != "*log.Logger" || info.Type != "*log.Logger" || info.MinLevel != nil || info.Middleware != 0 || info.Stats != nil {
+		t.Errorf("Unexpected console handler info '%+v'", info)
+	}
+	assertLevels(t, info.Levels, []Level{DebugLevel, ErrorLevel})
+
+	info = infos[1]
+	if info.Handler != async || info.Name != "async(named)" || info.Type != "*log.AsyncHandler" || info.MinLevel != v || info.Middleware != 1 {
+		t.Errorf("Unexpected async handler info '%+v'", info)
+	}
+	if info.Stats["dropped"] != 0 || info.Stats["queued"] != 0 {
+		t.Errorf("Expected empty stats Got '%v'", info.Stats)
+	}
+	assertLevels(t, info.Levels, LevelsAtLeast(WarnLevel))
+
+	l.RemoveHandler(async)
+	if infos = l.Handlers(); len(infos) != 1 || infos[0].Handler != console {
+		t.Errorf("Expected only the console handler Got '%+v'", infos)
+	}
+}
+
+func TestHandlersNonComparable(t *testing.T) {
+	l := New()
+	var entries []string
+	l.AddHandler(valueHandler{entries: &entries}, InfoLevel, ErrorLevel)
+	console := NewConsoleBuilder().WithWriter(new(bytes.Buffer)).Build()
+	l.AddHandler(console, InfoLevel, ErrorLevel)
+
+	infos := l.Handlers()
+	if len(infos) != 3 {
+		t.Fatalf("Expected 3 handlers Got %d", len(infos))
+	}
+	if infos[0].Type != "log.valueHandler" || infos[1].Handler != console || infos[2].Type != "log.valueHandler" {
+		t.Errorf("Unexpected handlers '%+v'", infos)
+	}
+	assertLevels(t, infos[0].Levels, []Level{InfoLevel})
+	assertLevels(t, infos[1].Levels, []Level{InfoLevel, ErrorLevel})
+	assertLevels(t, infos[2].Levels, []Level{ErrorLevel})
+}
@@ -0,0 +1,365 @@
+package log
+
+import (
+	"os"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var defaultInstance atomic.Value
+
+// Default returns the Instance used by the package level functions.
+func Default() *Instance {
+	return defaultInstance.Load().(*Instance)
+}
+
+// SetDefault replaces the Instance used by the package level functions.
+func SetDefault(l *Instance) {
+	defaultInstance.Store(l)
+}
+
+// Instance is an independent logger with its own handlers, default fields, exit function and
+// WithError function. The package level functions delegate to the Default Instance.
+type Instance struct {
+	// m serializes changes to the handler snapshot, logging only ever loads the current snapshot.
+	m                sync.Mutex
+	state            atomic.Value
+	fields           []Field
+	exitFunc         func(code int)
+	withErrFn        func(Entry, error) Entry
+	defaultHandler   *Logger
+	exitFlushTimeout time.Duration
+}
+
+// New creates a new Instance with no handlers registered.
+func New() *Instance {
+	l := &Instance{
+		exitFunc:         os.Exit,
+		withErrFn:        errorsWithError,
+		exitFlushTimeout: defaultExitFlushTimeout,
+	}
+	l.state.Store(&snapshot{
+		handlers:  make(map[Level][]Handler),
+		errorFunc: NewErrorWriter(os.Stderr, defaultErrorInterval),
+	})
+	return l
+}
+
+// load returns the current handler snapshot.
+func (l *Instance) load() *snapshot {
+	return l.state.Load().(*snapshot)
+}
+
+// update applies fn to a copy of the current handler snapshot and atomically stores the result.
+func (l *Instance) update(fn func(s *snapshot)) {
+	l.m.Lock()
+	s := l.load().clone()
+	fn(s)
+	s.updateEnabled()
+	s.updateChains()
+	l.state.Store(s)
+	l.m.Unlock()
+}
+
+func (l *Instance) newEntry(fields ...Field) Entry {
+	e := Entry{
+		Fields:   make([]Field, len(fields)+len(l.fields)),
+		instance: l,
+	}
+	copy(e.Fields[copy(e.Fields, l.fields):], fields)
+	return e
+}
+
+// SetExitFunc sets the provided function as the exit function used in Fatal(),
+// Fatalf(), Panic() and Panicf() for this Instance.
+func (l *Instance) SetExitFunc(fn func(code int)) {
+	l.exitFunc = fn
+}
+
+// SetWithErrorFn sets a custom WithError function handlers for this Instance.
+func (l *Instance) SetWithErrorFn(fn func(Entry, error) Entry) {
+	l.withErrFn = fn
+}
+
+// HandleEntry handles the log entry and fans out to all of this Instance's handlers with the proper log level.
+func (l *Instance) HandleEntry(e Entry) {
+	if !e.start.IsZero() {
+		e = e.WithField("duration", time.Since(e.start))
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	if e.recording != nil {
+		var replay []Entry
+		var emit bool
+		replay, e, emit = e.recording.record(e)
+		for _, re := range replay {
+			l.process(re)
+		}
+		if !emit {
+			return
+		}
+	}
+	l.process(e)
+}
+
+// process applies the middleware, redaction and PII scanning to the entry before dispatching it.
+func (l *Instance) process(e Entry) {
+	s := l.load()
+	if len(s.middleware) > 0 {
+		var ok bool
+		if e, ok = applyMiddleware(s.middleware, e); !ok {
+			return
+		}
+	}
+	if s.redactor != nil {
+		e = s.redactor.Redact(e)
+	}
+	if s.piiScanner != nil {
+		e = s.piiScanner.Scan(e)
+	}
+
+	if s.async != nil {
+		s.async.Log(e)
+		return
+	}
+	l.dispatch(e)
+}
+
+// dispatch fans the entry out to the handlers registered for its level, or those of the trigger level
+// for entries replayed by a flight recording.
+func (l *Instance) dispatch(e Entry) {
+	s := l.load()
+	handlers, chains := s.handlers[e.Level], s.chains[e.Level]
+	if e.replayed {
+		handlers, chains = s.handlers[e.triggerLevel], s.chains[e.triggerLevel]
+	}
+	for i, h := range handlers {
+		if chains != nil && chains[i] != nil {
+			he, ok := applyMiddleware(chains[i], e)
+			if ok {
+				l.safeLog(h, he)
+			}
+			continue
+		}
+		l.safeLog(h, e)
+	}
+}
+
+// safeLog passes the entry to the handler, recovering any panic and reporting it to the error function
+// so that one misbehaving handler cannot affect the caller or other handlers.
+func (l *Instance) safeLog(h Handler, e Entry) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.load().errorFunc(h, e, &HandlerPanicError{Handler: h, Value: r, Stack: debug.Stack()})
+		}
+	}()
+	h.Log(e)
+}
+
+// Enabled returns if at least one handler is registered for the provided level. It does not take
+// any locks and so is cheap enough to guard expensive log calls with.
+func (l *Instance) Enabled(level Level) bool {
+	return l.load().enabledFor(level)
+}
+
+// AddHandler adds a new log handlers and accepts which log levels that
+// handlers will be triggered for
+func (l *Instance) AddHandler(h Handler, levels ...Level) {
+	l.update(func(s *snapshot) {
+		if l.defaultHandler != nil {
+			s.removeHandler(l.defaultHandler)
+			l.defaultHandler = nil
+		}
+		for _, level := range levels {
+			s.handlers[level] = append(s.handlers[level], h)
+		}
+	})
+}
+
+// RemoveHandler removes an existing handler
+func (l *Instance) RemoveHandler(h Handler) {
+	l.update(func(s *snapshot) {
+		s.removeHandler(h)
+	})
+}
+
+// removeHandlerLevels removes the supplied levels, if no more levels exists for the handler
+// it will no longer be registered and need to added via AddHandler again.
+func (l *Instance) removeHandlerLevels(h Handler, levels ...Level) {
+	l.update(func(s *snapshot) {
+		s.removeHandlerLevels(h, levels...)
+	})
+}
+
+// WithDefaultFields adds fields to this Instance that will be automatically added to ALL of its log entries.
+func (l *Instance) WithDefaultFields(fields ...Field) {
+	l.fields = append(l.fields, fields...)
+}
+
+// WithField returns a new log entry with the supplied field.
+func (l *Instance) WithField(key string, value interface{}) Entry {
+	ne := l.newEntry(Field{Key: key, Value: value})
+	return ne
+}
+
+// WithFields returns a new log entry with the supplied fields appended
+func (l *Instance) WithFields(fields ...Field) Entry {
+	ne := l.newEntry(fields...)
+	return ne
+}
+
+// WithTrace with add duration of how long the between this function call and
+// the subsequent log
+func (l *Instance) WithTrace() Entry {
+	ne := l.newEntry()
+	ne.start = time.Now()
+	return ne
+}
+
+// WithError add a minimal stack trace to the log Entry
+func (l *Instance) WithError(err error) Entry {
+	ne := l.newEntry()
+	return l.withErrFn(ne, err)
+}
+
+// Log logs an entry at the provided level.
+// see Entry.Log for details.
+func (l *Instance) Log(level Level, v ...interface{}) {
+	if l.Enabled(level) {
+		e := l.newLevelEntry()
+		e.Log(level, v...)
+	}
+}
+
+// Logf logs an entry at the provided level with formatting.
+// see Entry.Log for details.
+func (l *Instance) Logf(level Level, s string, v ...interface{}) {
+	if l.Enabled(level) {
+		e := l.newLevelEntry()
+		e.Logf(level, s, v...)
+	}
+}
+
+// Debug logs a debug entry
+func (l *Instance) Debug(v ...interface{}) {
+	if l.Enabled(DebugLevel) {
+		e := l.newLevelEntry()
+		e.Debug(v...)
+	}
+}
+
+// Debugf logs a debug entry with formatting
+func (l *Instance) Debugf(s string, v ...interface{}) {
+	if l.Enabled(DebugLevel) {
+		e := l.newLevelEntry()
+		e.Debugf(s, v...)
+	}
+}
+
+// Info logs a normal. information, entry
+func (l *Instance) Info(v ...interface{}) {
+	if l.Enabled(InfoLevel) {
+		e := l.newLevelEntry()
+		e.Info(v...)
+	}
+}
+
+// Infof logs a normal. information, entry with formatting
+func (l *Instance) Infof(s string, v ...interface{}) {
+	if l.Enabled(InfoLevel) {
+		e := l.newLevelEntry()
+		e.Infof(s, v...)
+	}
+}
+
+// Notice logs a notice log entry
+func (l *Instance) Notice(v ...interface{}) {
+	if l.Enabled(NoticeLevel) {
+		e := l.newLevelEntry()
+		e.Notice(v...)
+	}
+}
+
+// Noticef logs a notice log entry with formatting
+func (l *Instance) Noticef(s string, v ...interface{}) {
+	if l.Enabled(NoticeLevel) {
+		e := l.newLevelEntry()
+		e.Noticef(s, v...)
+	}
+}
+
+// Warn logs a warning log entry
+func (l *Instance) Warn(v ...interface{}) {
+	if l.Enabled(WarnLevel) {
+		e := l.newLevelEntry()
+		e.Warn(v...)
+	}
+}
+
+// Warnf logs a warning log entry with formatting
+func (l *Instance) Warnf(s string, v ...interface{}) {
+	if l.Enabled(WarnLevel) {
+		e := l.newLevelEntry()
+		e.Warnf(s, v...)
+	}
+}
+
+// Panic logs a panic log entry
+func (l *Instance) Panic(v ...interface{}) {
+	e := l.newLevelEntry()
+	e.Panic(v...)
+}
+
+// Panicf logs a panic log entry with formatting
+func (l *Instance) Panicf(s string, v ...interface{}) {
+	e := l.newLevelEntry()
+	e.Panicf(s, v...)
+}
+
+// Alert logs an alert log entry
+func (l *Instance) Alert(v ...interface{}) {
+	if l.Enabled(AlertLevel) {
+		e := l.newLevelEntry()
+		e.Alert(v...)
+	}
+}
+
+// Alertf logs an alert log entry with formatting
+func (l *Instance) Alertf(s string, v ...interface{}) {
+	if l.Enabled(AlertLevel) {
+		e := l.newLevelEntry()
+		e.Alertf(s, v...)
+	}
+}
+
+// Fatal logs a fatal log entry
+func (l *Instance) Fatal(v ...interface{}) {
+	e := l.newLevelEntry()
+	e.Fatal(v...)
+}
+
+// Fatalf logs a fatal log entry with formatting
+func (l *Instance) Fatalf(s string, v ...interface{}) {
+	e := l.newLevelEntry()
+	e.Fatalf(s, v...)
+}
+
+// Error logs an error log entry
+func (l *Instance) Error(v ...interface{}) {
+	if l.Enabled(ErrorLevel) {
+		e := l.newLevelEntry()
+		e.Error(v...)
+	}
+}
+
+// Errorf logs an error log entry with formatting
+func (l *Instance) Errorf(s string, v ...interface{}) {
+	if l.Enabled(ErrorLevel) {
+		e := l.newLevelEntry()
+		e.Errorf(s, v...)
+	}
+}
@@ -0,0 +1,129 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestInstanceIsolation(t *testing.T) {
+	SetDefault(New())
+
+	var defBuff, instBuff bytes.Buffer
+	AddHandler(&testHandler{writer: &defBuff}, AllLevels...)
+
+	var code int
+	l := New()
+	l.SetExitFunc(func(c int) { code = c })
+	l.WithDefaultFields(F("instance", true))
+	l.AddHandler(&testHandler{writer: &instBuff}, AllLevels...)
+
+	l.WithField("key", "value").Info("instance")
+	if instBuff.String() != "INFO instance instance=true key=value\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO instance instance=true key=value\n", instBuff.String())
+	}
+	if defBuff.Len() != 0 {
+		t.Errorf("Expected default instance to receive nothing Got '%s'", defBuff.String())
+	}
+
+	instBuff.Reset()
+	Info("default")
+	if defBuff.String() != "INFO default\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO default\n", defBuff.String())
+	}
+	if instBuff.Len() != 0 {
+		t.Errorf("Expected instance to receive nothing Got '%s'", instBuff.String())
+	}
+
+	l.Fatal("fatal")
+	if code != 1 {
+		t.Errorf("Expected exit code '%d' Got '%d'", 1, code)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	l := New()
+	SetDefault(l)
+	if Default() != l {
+		t.Error("expected Default to return the Instance passed to SetDefault")
+	}
+
+	var buff bytes.Buffer
+	l.AddHandler(&testHandler{writer: &buff}, InfoLevel)
+	var e Entry
+	e.Info("zero entry")
+	if buff.String() != "INFO zero entry\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO zero entry\n", buff.String())
+	}
+}
+
+type countingStringer struct {
+	calls int
+}
+
+func (c *countingStringer) String() string {
+	c.calls++
+	return "formatted"
+}
+
+func TestEnabled(t *testing.T) {
+	l := New()
+	var code int
+	l.SetExitFunc(func(c int) { code = c })
+
+	buff := new(bytes.Buffer)
+	th := &testHandler{writer: buff}
+	l.AddHandler(th, InfoLevel, ErrorLevel)
+	for _, lvl := range AllLevels {
+		want := lvl == InfoLevel || lvl == ErrorLevel
+		if l.Enabled(lvl) != want {
+			t.Errorf("level %s: Expected enabled '%t' Got '%t'", lvl, want, l.Enabled(lvl))
+		}
+	}
+
+	s := &countingStringer{}
+	l.Debugf("%s", s)
+	l.WithField("key", "value").Debug(s)
+	if s.calls != 0 || buff.Len() != 0 {
+		t.Errorf("Expected disabled level to skip formatting Got '%d' calls and '%s'", s.calls, buff.String())
+	}
+
+	l.Fatal(s)
+	if code != 1 || s.calls != 0 {
+		t.Errorf("Expected disabled Fatal to still exit without formatting Got code '%d' calls '%d'", code, s.calls)
+	}
+
+	l.Infof("%s", s)
+	if s.calls != 1 || buff.String() != "INFO formatted\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO formatted\n", buff.String())
+	}
+
+	l.removeHandlerLevels(th, InfoLevel)
+	if l.Enabled(InfoLevel) || !l.Enabled(ErrorLevel) {
+		t.Error("Expected only ErrorLevel to remain enabled")
+	}
+	l.RemoveHandler(th)
+	if l.Enabled(ErrorLevel) {
+		t.Error("Expected no levels enabled")
+	}
+}
+
+func TestConcurrentHandlerChanges(t *testing.T) {
+	l := New()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			h := &testHandler{writer: new(bytes.Buffer)}
+			l.AddHandler(h, AllLevels...)
+			l.removeHandlerLevels(h, DebugLevel)
+			l.RemoveHandler(h)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		l.Info("concurrent")
+	}
+	<-done
+	if len(l.load().handlers) != 0 {
+		t.Errorf("Expected no handlers Got '%d' levels", len(l.load().handlers))
+	}
+}
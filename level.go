@@ -2,10 +2,15 @@ package log
 
 import (
 	"bytes"
+	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
-// AllLevels is an array of all log levels, for easier registering of all levels to a handler
+// AllLevels is an array of all log levels, for easier registering of all levels to a handler.
+// It is ordered by severity and includes levels added using RegisterLevel.
 var AllLevels = []Level{
 	DebugLevel,
 	InfoLevel,
@@ -32,51 +37,189 @@ const (
 	FatalLevel // same as syslog CRITICAL
 )
 
+// Extended log levels, registered using RegisterExtendedLevels.
+const (
+	TraceLevel Level = iota + 8
+	AuditLevel
+	SecurityLevel
+)
+
+// LevelDefinition describes a Level.
+type LevelDefinition struct {
+	// Name is the upper case name used by String, ParseLevel and JSON encoding.
+	Name string
+	// Severity orders levels, higher is more severe. The built-in levels use multiples of 10 starting
+	// with 0 for DebugLevel.
+	Severity int
+	// Slog is the equivalent slog.Level value.
+	Slog int
+	// Syslog is the equivalent syslog severity, from 0 (emergency) to 7 (debug).
+	Syslog int
+}
+
+type levelRegistry struct {
+	defs   [256]*LevelDefinition
+	byName map[string]Level
+	width  int
+}
+
+var (
+	levelsM sync.Mutex
+	levels  = newLevels()
+)
+
+func newLevels() *atomic.Value {
+	r := &levelRegistry{byName: make(map[string]Level)}
+	for _, d := range []struct {
+		level Level
+		def   LevelDefinition
+	}{
+		{DebugLevel, LevelDefinition{Name: "DEBUG", Severity: 0, Slog: -4, Syslog: 7}},
+		{InfoLevel, LevelDefinition{Name: "INFO", Severity: 10, Slog: 0, Syslog: 6}},
+		{NoticeLevel, LevelDefinition{Name: "NOTICE", Severity: 20, Slog: 2, Syslog: 5}},
+		{WarnLevel, LevelDefinition{Name: "WARN", Severity: 30, Slog: 4, Syslog: 4}},
+		{ErrorLevel, LevelDefinition{Name: "ERROR", Severity: 40, Slog: 8, Syslog: 3}},
+		{PanicLevel, LevelDefinition{Name: "PANIC", Severity: 50, Slog: 12, Syslog: 2}},
+		{AlertLevel, LevelDefinition{Name: "ALERT", Severity: 60, Slog: 16, Syslog: 1}},
+		{FatalLevel, LevelDefinition{Name: "FATAL", Severity: 70, Slog: 20, Syslog: 2}},
+	} {
+		r.add(d.level, d.def)
+	}
+	v := new(atomic.Value)
+	v.Store(r)
+	return v
+}
+
+func loadLevels() *levelRegistry {
+	return levels.Load().(*levelRegistry)
+}
+
+func (r *levelRegistry) add(level Level, def LevelDefinition) {
+	r.defs[level] = &def
+	r.byName[def.Name] = level
+	if len(def.Name) > r.width {
+		r.width = len(def.Name)
+	}
+}
+
+// RegisterLevel registers a custom Level, making it known to String, ParseLevel, JSON encoding, the slog
+// bridge and ordering by severity. An error is returned if the level or its name is already registered.
+//
+// Levels should be registered during program initialization, before they are used, as AllLevels is
+// replaced to include them.
+func RegisterLevel(level Level, def LevelDefinition) error {
+	def.Name = strings.ToUpper(def.Name)
+	if def.Name == "" {
+		return fmt.Errorf("log: level %d must have a name", level)
+	}
+	if level == 255 {
+		return fmt.Errorf("log: level 255 is reserved for unknown levels")
+	}
+
+	levelsM.Lock()
+	defer levelsM.Unlock()
+
+	current := loadLevels()
+	if current.defs[level] != nil {
+		return fmt.Errorf("log: level %d is already registered as %s", level, current.defs[level].Name)
+	}
+	if _, found := current.byName[def.Name]; found {
+		return fmt.Errorf("log: level name %s is already registered", def.Name)
+	}
+
+	r := &levelRegistry{
+		defs:   current.defs,
+		byName: make(map[string]Level, len(current.byName)+1),
+		width:  current.width,
+	}
+	for name, lvl := range current.byName {
+		r.byName[name] = lvl
+	}
+	r.add(level, def)
+	levels.Store(r)
+
+	all := make([]Level, 0, len(r.byName))
+	for _, lvl := range r.byName {
+		all = append(all, lvl)
+	}
+	sortLevels(all)
+	AllLevels = all
+	return nil
+}
+
+// sortLevels sorts the levels by severity.
+func sortLevels(levels []Level) {
+	sort.Slice(levels, func(i, j int) bool {
+		si, sj := levels[i].Severity(), levels[j].Severity()
+		if si == sj {
+			return levels[i] < levels[j]
+		}
+		return si < sj
+	})
+}
+
+// LevelsAtLeast returns the registered levels at least as severe as the provided level, ordered by
+// severity.
+func LevelsAtLeast(min Level) []Level {
+	r := loadLevels()
+	severity := min.Severity()
+	var levels []Level
+	for i, def := range r.defs {
+		if def != nil && def.Severity >= severity {
+			levels = append(levels, Level(i))
+		}
+	}
+	sortLevels(levels)
+	return levels
+}
+
+// RegisterExtendedLevels registers TraceLevel below DebugLevel, AuditLevel between NoticeLevel and
+// WarnLevel and SecurityLevel between ErrorLevel and PanicLevel.
+func RegisterExtendedLevels() error {
+	if err := RegisterLevel(TraceLevel, LevelDefinition{Name: "TRACE", Severity: -10, Slog: -8, Syslog: 7}); err != nil {
+		return err
+	}
+	if err := RegisterLevel(AuditLevel, LevelDefinition{Name: "AUDIT", Severity: 25, Slog: 3, Syslog: 5}); err != nil {
+		return err
+	}
+	return RegisterLevel(SecurityLevel, LevelDefinition{Name: "SECURITY", Severity: 45, Slog: 10, Syslog: 3})
+}
+
+// Definition returns the registered definition of the level.
+func (l Level) Definition() (LevelDefinition, bool) {
+	if def := loadLevels().defs[l]; def != nil {
+		return *def, true
+	}
+	return LevelDefinition{}, false
+}
+
+// Severity returns the registered severity of the level, unregistered levels are ordered as though they
+// continue the built-in levels.
+func (l Level) Severity() int {
+	if def := loadLevels().defs[l]; def != nil {
+		return def.Severity
+	}
+	return int(l) * 10
+}
+
+// levelNameWidth returns the length of the longest registered level name.
+func levelNameWidth() int {
+	return loadLevels().width
+}
+
 func (l Level) String() string {
-	switch l {
-	case DebugLevel:
-		return "DEBUG"
-	case InfoLevel:
-		return "INFO"
-	case NoticeLevel:
-		return "NOTICE"
-	case WarnLevel:
-		return "WARN"
-	case ErrorLevel:
-		return "ERROR"
-	case PanicLevel:
-		return "PANIC"
-	case AlertLevel:
-		return "ALERT"
-	case FatalLevel:
-		return "FATAL"
-	default:
-		return "Unknown Level"
+	if def := loadLevels().defs[l]; def != nil {
+		return def.Name
 	}
+	return "Unknown Level"
 }
 
 // ParseLevel parses the provided strings log level or if not supported return 255
 func ParseLevel(s string) Level {
-	switch strings.ToUpper(s) {
-	case "DEBUG":
-		return DebugLevel
-	case "INFO":
-		return InfoLevel
-	case "NOTICE":
-		return NoticeLevel
-	case "WARN":
-		return WarnLevel
-	case "ERROR":
-		return ErrorLevel
-	case "PANIC":
-		return PanicLevel
-	case "ALERT":
-		return AlertLevel
-	case "FATAL":
-		return FatalLevel
-	default:
-		return 255
+	if level, found := loadLevels().byName[strings.ToUpper(s)]; found {
+		return level
 	}
+	return 255
 }
 
 // MarshalJSON implementation.
@@ -89,3 +232,21 @@ func (l *Level) UnmarshalJSON(b []byte) error {
 	*l = ParseLevel(string(bytes.Trim(b, `"`)))
 	return nil
 }
+
+// levelSet is a bitset of levels.
+type levelSet [4]uint64
+
+// add adds the level to the set.
+func (s *levelSet) add(l Level) {
+	s[l>>6] |= 1 << (l & 63)
+}
+
+// remove removes the level from the set.
+func (s *levelSet) remove(l Level) {
+	s[l>>6] &^= 1 << (l & 63)
+}
+
+// has returns if the level is in the set.
+func (s *levelSet) has(l Level) bool {
+	return s[l>>6]&(1<<(l&63)) != 0
+}
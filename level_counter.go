@@ -0,0 +1,31 @@
+package log
+
+import "sync/atomic"
+
+// LevelCounter counts the entries logged per level. It is opt-in, as every entry then updates a shared
+// counter, and is applied using Use(counter.Middleware) after any middleware that drops entries.
+type LevelCounter struct {
+	counts [256]uint64
+}
+
+// NewLevelCounter creates a new LevelCounter.
+func NewLevelCounter() *LevelCounter {
+	return new(LevelCounter)
+}
+
+// Middleware counts the entry, it never drops entries.
+func (c *LevelCounter) Middleware(e Entry) (Entry, bool) {
+	atomic.AddUint64(&c.counts[e.Level], 1)
+	return e, true
+}
+
+// Counts returns the number of entries counted for each level that has had at least one.
+func (c *LevelCounter) Counts() map[Level]uint64 {
+	counts := make(map[Level]uint64)
+	for i := range c.counts {
+		if n := atomic.LoadUint64(&c.counts[i]); n > 0 {
+			counts[Level(i)] = n
+		}
+	}
+	return counts
+}
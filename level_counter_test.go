@@ -0,0 +1,23 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLevelCounter(t *testing.T) {
+	l := New()
+	l.AddHandler(&testHandler{writer: new(bytes.Buffer)}, AllLevels...)
+	c := NewLevelCounter()
+	l.Use(func(e Entry) (Entry, bool) { return e, e.Message != "dropped" }, c.Middleware)
+
+	l.Info("one")
+	l.Info("dropped")
+	l.Warn("two")
+	l.Debug("three")
+
+	counts := c.Counts()
+	if len(counts) != 3 || counts[InfoLevel] != 1 || counts[WarnLevel] != 1 || counts[DebugLevel] != 1 {
+		t.Errorf("Unexpected counts '%v'", counts)
+	}
+}
@@ -0,0 +1,140 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// registerExtendedLevels registers the extended levels for the duration of the test.
+func registerExtendedLevels(t *testing.T) {
+	t.Helper()
+	saved, savedAll := levels.Load(), AllLevels
+	t.Cleanup(func() {
+		levels.Store(saved)
+		AllLevels = savedAll
+	})
+	if err := RegisterExtendedLevels(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterLevel(t *testing.T) {
+	registerExtendedLevels(t)
+
+	tests := []struct {
+		level Level
+		name  string
+	}{
+		{level: TraceLevel, name: "TRACE"},
+		{level: AuditLevel, name: "AUDIT"},
+		{level: SecurityLevel, name: "SECURITY"},
+		{level: DebugLevel, name: "DEBUG"},
+	}
+	for _, tt := range tests {
+		if tt.level.String() != tt.name {
+			t.Errorf("Expected '%s' Got '%s'", tt.name, tt.level.String())
+		}
+		if ParseLevel(tt.name) != tt.level {
+			t.Errorf("Expected '%d' Got '%d'", tt.level, ParseLevel(tt.name))
+		}
+
+		b, err := json.Marshal(tt.level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var level Level
+		if err = json.Unmarshal(b, &level); err != nil {
+			t.Fatal(err)
+		}
+		if level != tt.level {
+			t.Errorf("Expected '%d' Got '%d'", tt.level, level)
+		}
+	}
+
+	expected := []Level{TraceLevel, DebugLevel, InfoLevel, NoticeLevel, AuditLevel, WarnLevel, ErrorLevel, SecurityLevel, PanicLevel, AlertLevel, FatalLevel}
+	if len(AllLevels) != len(expected) {
+		t.Fatalf("Expected '%v' Got '%v'", expected, AllLevels)
+	}
+	for i := range expected {
+		if AllLevels[i] != expected[i] {
+			t.Errorf("Expected '%v' Got '%v'", expected, AllLevels)
+			break
+		}
+	}
+
+	if TraceLevel.Severity() >= DebugLevel.Severity() {
+		t.Errorf("Expected TRACE to be less severe than DEBUG")
+	}
+
+	if err := RegisterLevel(TraceLevel, LevelDefinition{Name: "OTHER"}); err == nil {
+		t.Errorf("Expected error registering a level twice")
+	}
+	if err := RegisterLevel(Level(20), LevelDefinition{Name: "trace"}); err == nil {
+		t.Errorf("Expected error registering a name twice")
+	}
+	if err := RegisterLevel(Level(20), LevelDefinition{}); err == nil {
+		t.Errorf("Expected error registering without a name")
+	}
+	if Level(20).String() != "Unknown Level" {
+		t.Errorf("Expected '%s' Got '%s'", "Unknown Level", Level(20).String())
+	}
+}
+
+func TestRegisterLevelConsolePadding(t *testing.T) {
+	registerExtendedLevels(t)
+
+	buff := new(bytes.Buffer)
+	l := New()
+	l.AddHandler(NewConsoleBuilder().WithWriter(buff).WithTimestampFormat("2006").Build(), AllLevels...)
+	l.HandleEntry(Entry{Level: TraceLevel, Message: "trace", Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+	l.HandleEntry(Entry{Level: SecurityLevel, Message: "security", Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	expected := "2024    TRACE trace\n2024 SECURITY security\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+func TestRegisterLevelSeverityOrdering(t *testing.T) {
+	registerExtendedLevels(t)
+
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(&testHandler{writer: buff}, AllLevels...)
+	// AuditLevel is numerically higher than ErrorLevel but less severe
+	rec := NewFlightRecorderBuilder().WithLevels(TraceLevel).WithTriggerLevel(ErrorLevel).Build()
+	ctx, end := rec.Start(context.Background())
+	defer end()
+
+	l.Ctx(ctx).Log(TraceLevel, "trace")
+	l.Ctx(ctx).Logf(AuditLevel, "%s", "audit")
+	l.Ctx(ctx).Log(ErrorLevel, "error")
+
+	expected := "AUDIT audit\nTRACE trace\nERROR error\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+func TestLevelSet(t *testing.T) {
+	var s levelSet
+	levels := []Level{DebugLevel, 63, 64, 255}
+	for _, l := range levels {
+		s.add(l)
+	}
+	for _, l := range levels {
+		if !s.has(l) {
+			t.Errorf("Expected level %d in set", l)
+		}
+	}
+	if s.has(InfoLevel) || s.has(65) {
+		t.Errorf("Unexpected level in set '%v'", s)
+	}
+	s.remove(64)
+	if s.has(64) || !s.has(63) || !s.has(255) {
+		t.Errorf("Expected only level 64 removed Got '%v'", s)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"sync"
 	"time"
+	"unsafe"
 
 	"golang.org/x/term"
 )
@@ -17,15 +18,16 @@ var (
 			}
 		},
 	}}
-	defaultHandler *Logger
 )
 
 func init() {
+	l := New()
 	if term.IsTerminal(int(os.Stdin.Fd())) {
 		h := NewConsoleBuilder().Build()
-		AddHandler(h, AllLevels...)
-		defaultHandler = h
+		l.AddHandler(h, AllLevels...)
+		l.defaultHandler = h
 	}
+	SetDefault(l)
 }
 
 const (
@@ -35,22 +37,25 @@ const (
 )
 
 var (
-	logFields   []Field
-	logHandlers = map[Level][]Handler{}
-	exitFunc    = os.Exit
-	withErrFn   = errorsWithError
-	ctxIdent    = &struct {
+	ctxIdent = &struct {
 		name string
 	}{
 		name: "log",
 	}
-	rw = new(sync.RWMutex)
 )
 
-// Field is a single Field key and value
+// Field is a single Field key and value.
+//
+// Fields created using the typed constructors, such as String and Int64, store their value without
+// boxing it into Value, handlers should use Kind and the typed accessors or Interface to read them.
 type Field struct {
 	Key   string      `json:"key"`
 	Value interface{} `json:"value"`
+	kind  FieldKind
+	// num is the value of numeric kinds, the length of a string or the nanoseconds of a time.
+	num uint64
+	// ptr is the data of a string or the location of a time.
+	ptr unsafe.Pointer
 }
 
 // SetExitFunc sets the provided function as the exit function used in Fatal(),
@@ -58,12 +63,12 @@ type Field struct {
 // you can set this to enable testing (with coverage) of your Fatal() and Fatalf()
 // methods.
 func SetExitFunc(fn func(code int)) {
-	exitFunc = fn
+	Default().SetExitFunc(fn)
 }
 
 // SetWithErrorFn sets a custom WithError function handlers
 func SetWithErrorFn(fn func(Entry, error) Entry) {
-	withErrFn = fn
+	Default().SetWithErrorFn(fn)
 }
 
 // SetContext sets a log entry into the provided context
@@ -72,13 +77,10 @@ func SetContext(ctx context.Context, e Entry) context.Context {
 }
 
 // GetContext returns the log Entry found in the context,
-// or a new Default log Entry if none is found
+// or a new Default log Entry if none is found, with the fields
+// of any registered context extractors added.
 func GetContext(ctx context.Context) Entry {
-	v := ctx.Value(ctxIdent)
-	if v == nil {
-		return newEntry()
-	}
-	return v.(Entry)
+	return Default().GetContext(ctx)
 }
 
 // BytePool returns a sync.Pool of bytes that multiple handlers can use in order to reduce allocation and keep
@@ -91,18 +93,7 @@ func BytePool() *byteArrayPool {
 // This is exposed to allow for centralized logging whereby the log entry is marshalled, passed
 // to a central logging server, unmarshalled and finally fanned out from there.
 func HandleEntry(e Entry) {
-	if !e.start.IsZero() {
-		e = e.WithField("duration", time.Since(e.start))
-	}
-	if e.Timestamp.IsZero() {
-		e.Timestamp = time.Now()
-	}
-
-	rw.RLock()
-	for _, h := range logHandlers[e.Level] {
-		h.Log(e)
-	}
-	rw.RUnlock()
+	Default().HandleEntry(e)
 }
 
 // F creates a new Field using the supplied key + value.
@@ -116,195 +107,223 @@ func G(key string, fields ...Field) Field {
 	return F(key, fields)
 }
 
+// Enabled returns if at least one handler is registered for the provided level on the Default Instance.
+func Enabled(level Level) bool {
+	return Default().Enabled(level)
+}
+
 // AddHandler adds a new log handlers and accepts which log levels that
 // handlers will be triggered for
 func AddHandler(h Handler, levels ...Level) {
-	rw.Lock()
-	defer rw.Unlock()
-	if defaultHandler != nil {
-		removeHandler(defaultHandler)
-		defaultHandler = nil
-	}
-	for _, level := range levels {
-		handler := append(logHandlers[level], h)
-		logHandlers[level] = handler
-	}
+	Default().AddHandler(h, levels...)
 }
 
 // RemoveHandler removes an existing handler
 func RemoveHandler(h Handler) {
-	rw.Lock()
-	removeHandler(h)
-	rw.Unlock()
-}
-
-func removeHandler(h Handler) {
-OUTER:
-	for lvl, handlers := range logHandlers {
-		for i, handler := range handlers {
-			if h == handler {
-				n := append(handlers[:i], handlers[i+1:]...)
-				if len(n) == 0 {
-					delete(logHandlers, lvl)
-					continue OUTER
-				}
-				logHandlers[lvl] = n
-				continue OUTER
-			}
-		}
-	}
-}
-
-// removeHandlerLevels removes the supplied levels, if no more levels exists for the handler
-// it will no longer be registered and need to added via AddHandler again.
-func removeHandlerLevels(h Handler, levels ...Level) {
-	rw.Lock()
-	defer rw.Unlock()
-OUTER:
-	for _, lvl := range levels {
-		handlers := logHandlers[lvl]
-		for i, handler := range handlers {
-			if h == handler {
-				n := append(handlers[:i], handlers[i+1:]...)
-				if len(n) == 0 {
-					delete(logHandlers, lvl)
-					continue OUTER
-				}
-				logHandlers[lvl] = n
-				continue OUTER
-			}
-		}
-	}
+	Default().RemoveHandler(h)
 }
 
 // WithDefaultFields adds fields to the underlying logger instance that will be automatically added to ALL log entries.
 func WithDefaultFields(fields ...Field) {
-	logFields = append(logFields, fields...)
+	Default().WithDefaultFields(fields...)
 }
 
 // WithField returns a new log entry with the supplied field.
 func WithField(key string, value interface{}) Entry {
-	ne := newEntry(Field{Key: key, Value: value})
+	ne := Default().newEntry(Field{Key: key, Value: value})
 	return ne
 }
 
 // WithFields returns a new log entry with the supplied fields appended
 func WithFields(fields ...Field) Entry {
-	ne := newEntry(fields...)
+	ne := Default().newEntry(fields...)
 	return ne
 }
 
 // WithTrace with add duration of how long the between this function call and
 // the subsequent log
 func WithTrace() Entry {
-	ne := newEntry()
+	ne := Default().newEntry()
 	ne.start = time.Now()
 	return ne
 }
 
 // WithError add a minimal stack trace to the log Entry
 func WithError(err error) Entry {
-	ne := newEntry()
-	return withErrFn(ne, err)
+	l := Default()
+	ne := l.newEntry()
+	return l.withErrFn(ne, err)
+}
+
+// Log logs an entry at the provided level.
+// see Entry.Log for details.
+func Log(level Level, v ...interface{}) {
+	if l := Default(); l.Enabled(level) {
+		e := l.newLevelEntry()
+		e.Log(level, v...)
+	}
+}
+
+// Logf logs an entry at the provided level with formatting.
+// see Entry.Log for details.
+func Logf(level Level, s string, v ...interface{}) {
+	if l := Default(); l.Enabled(level) {
+		e := l.newLevelEntry()
+		e.Logf(level, s, v...)
+	}
 }
 
 // Debug logs a debug entry
 func Debug(v ...interface{}) {
-	e := newEntry()
-	e.Debug(v...)
+	if l := Default(); l.Enabled(DebugLevel) {
+		e := l.newLevelEntry()
+		e.Debug(v...)
+	}
 }
 
 // Debugf logs a debug entry with formatting
 func Debugf(s string, v ...interface{}) {
-	e := newEntry()
-	e.Debugf(s, v...)
+	if l := Default(); l.Enabled(DebugLevel) {
+		e := l.newLevelEntry()
+		e.Debugf(s, v...)
+	}
 }
 
 // Info logs a normal. information, entry
 func Info(v ...interface{}) {
-	e := newEntry()
-	e.Info(v...)
+	if l := Default(); l.Enabled(InfoLevel) {
+		e := l.newLevelEntry()
+		e.Info(v...)
+	}
 }
 
 // Infof logs a normal. information, entry with formatting
 func Infof(s string, v ...interface{}) {
-	e := newEntry()
-	e.Infof(s, v...)
+	if l := Default(); l.Enabled(InfoLevel) {
+		e := l.newLevelEntry()
+		e.Infof(s, v...)
+	}
 }
 
 // Notice logs a notice log entry
 func Notice(v ...interface{}) {
-	e := newEntry()
-	e.Notice(v...)
+	if l := Default(); l.Enabled(NoticeLevel) {
+		e := l.newLevelEntry()
+		e.Notice(v...)
+	}
 }
 
 // Noticef logs a notice log entry with formatting
 func Noticef(s string, v ...interface{}) {
-	e := newEntry()
-	e.Noticef(s, v...)
+	if l := Default(); l.Enabled(NoticeLevel) {
+		e := l.newLevelEntry()
+		e.Noticef(s, v...)
+	}
 }
 
 // Warn logs a warning log entry
 func Warn(v ...interface{}) {
-	e := newEntry()
-	e.Warn(v...)
+	if l := Default(); l.Enabled(WarnLevel) {
+		e := l.newLevelEntry()
+		e.Warn(v...)
+	}
 }
 
 // Warnf logs a warning log entry with formatting
 func Warnf(s string, v ...interface{}) {
-	e := newEntry()
-	e.Warnf(s, v...)
+	if l := Default(); l.Enabled(WarnLevel) {
+		e := l.newLevelEntry()
+		e.Warnf(s, v...)
+	}
 }
 
 // Panic logs a panic log entry
 func Panic(v ...interface{}) {
-	e := newEntry()
+	e := Default().newLevelEntry()
 	e.Panic(v...)
 }
 
 // Panicf logs a panic log entry with formatting
 func Panicf(s string, v ...interface{}) {
-	e := newEntry()
+	e := Default().newLevelEntry()
 	e.Panicf(s, v...)
 }
 
 // Alert logs an alert log entry
 func Alert(v ...interface{}) {
-	e := newEntry()
-	e.Alert(v...)
+	if l := Default(); l.Enabled(AlertLevel) {
+		e := l.newLevelEntry()
+		e.Alert(v...)
+	}
 }
 
 // Alertf logs an alert log entry with formatting
 func Alertf(s string, v ...interface{}) {
-	e := newEntry()
-	e.Alertf(s, v...)
+	if l := Default(); l.Enabled(AlertLevel) {
+		e := l.newLevelEntry()
+		e.Alertf(s, v...)
+	}
 }
 
 // Fatal logs a fatal log entry
 func Fatal(v ...interface{}) {
-	e := newEntry()
+	e := Default().newLevelEntry()
 	e.Fatal(v...)
 }
 
 // Fatalf logs a fatal log entry with formatting
 func Fatalf(s string, v ...interface{}) {
-	e := newEntry()
+	e := Default().newLevelEntry()
 	e.Fatalf(s, v...)
 }
 
 // Error logs an error log entry
 func Error(v ...interface{}) {
-	e := newEntry()
-	e.Error(v...)
+	if l := Default(); l.Enabled(ErrorLevel) {
+		e := l.newLevelEntry()
+		e.Error(v...)
+	}
 }
 
 // Errorf logs an error log entry with formatting
 func Errorf(s string, v ...interface{}) {
-	e := newEntry()
-	e.Errorf(s, v...)
+	if l := Default(); l.Enabled(ErrorLevel) {
+		e := l.newLevelEntry()
+		e.Errorf(s, v...)
+	}
 }
 
 // Handler is an interface that log handlers need to comply with
 type Handler interface {
 	Log(Entry)
 }
+
+// Flusher is an optional interface handlers can implement to flush any buffered entries.
+// It is called by Flush, Shutdown and before exiting in Fatal and Panic.
+type Flusher interface {
+	Flush() error
+}
+
+// Closer is an optional interface handlers can implement to release any resources they hold.
+// It is called by Shutdown.
+type Closer interface {
+	Close() error
+}
+
+// Flush flushes all handlers of the Default Instance.
+// see Instance.Flush for details.
+func Flush(ctx context.Context) error {
+	return Default().Flush(ctx)
+}
+
+// Shutdown drains, flushes and closes all handlers of the Default Instance.
+// see Instance.Shutdown for details.
+func Shutdown(ctx context.Context) error {
+	return Default().Shutdown(ctx)
+}
+
+// SetExitFlushTimeout sets the maximum time Fatal(), Fatalf(), Panic() and Panicf() will wait for
+// handlers to flush before calling the exit function.
+func SetExitFlushTimeout(d time.Duration) {
+	Default().SetExitFlushTimeout(d)
+}
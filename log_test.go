@@ -31,7 +31,7 @@ func (th *testHandler) Log(e Entry) {
 	s := e.Level.String() + " "
 	s += e.Message
 	for _, f := range e.Fields {
-		s += fmt.Sprintf(" %s=%v", f.Key, f.Value)
+		s += fmt.Sprintf(" %s=%v", f.Key, f.Interface())
 	}
 
 	s += "\n"
@@ -41,6 +41,7 @@ func (th *testHandler) Log(e Entry) {
 }
 
 func TestConsoleLogger1(t *testing.T) {
+	SetDefault(New())
 	SetExitFunc(func(int) {})
 	SetWithErrorFn(errorsWithError)
 
@@ -49,7 +50,6 @@ func TestConsoleLogger1(t *testing.T) {
 	th := &testHandler{
 		writer: buff,
 	}
-	logHandlers = map[Level][]Handler{}
 	AddHandler(th, AllLevels...)
 	for i, tt := range tests {
 		buff.Reset()
@@ -125,13 +125,13 @@ func TestConsoleLogger1(t *testing.T) {
 }
 
 func TestConsoleLogger2(t *testing.T) {
+	SetDefault(New())
 	SetExitFunc(func(int) {})
 	tests := getLogTests()
 	buff := new(bytes.Buffer)
 	th := &testHandler{
 		writer: buff,
 	}
-	logHandlers = map[Level][]Handler{}
 	AddHandler(th, AllLevels...)
 
 	for i, tt := range tests {
@@ -350,7 +350,7 @@ func TestFatal(t *testing.T) {
 }
 
 func TestWithError(t *testing.T) {
-	logHandlers = map[Level][]Handler{}
+	SetDefault(New())
 	buff := new(bytes.Buffer)
 	th := &testHandler{
 		writer: buff,
@@ -375,7 +375,7 @@ func TestWithError(t *testing.T) {
 }
 
 func TestWithTrace(t *testing.T) {
-	logHandlers = map[Level][]Handler{}
+	SetDefault(New())
 	buff := new(bytes.Buffer)
 	th := &testHandler{
 		writer: buff,
@@ -394,7 +394,7 @@ func TestWithTrace(t *testing.T) {
 }
 
 func TestDefaultsAndGroupFields(t *testing.T) {
-	logHandlers = map[Level][]Handler{}
+	SetDefault(New())
 	buff := new(bytes.Buffer)
 	th := &testHandler{
 		writer: buff,
@@ -980,19 +980,18 @@ func TestParseLevel(t *testing.T) {
 }
 
 func TestWrappedError(t *testing.T) {
+	SetDefault(New())
 	SetExitFunc(func(int) {})
 	SetWithErrorFn(errorsWithError)
-	logFields = logFields[0:0]
 	buff := new(bytes.Buffer)
 	th := &testHandler{
 		writer: buff,
 	}
-	logHandlers = map[Level][]Handler{}
 	AddHandler(th, AllLevels...)
 	err := fmt.Errorf("this is an %s", "error")
 	err = errors.Wrap(err, "prefix").AddTypes("Permanent", "Internal").AddTag("key", "value")
 	WithError(err).Error("test")
-	expected := "log_test.go:993:TestWrappedError prefix key=value types=Permanent,Internal\n"
+	expected := "log_test.go:992:TestWrappedError prefix key=value types=Permanent,Internal\n"
 	if !strings.HasSuffix(buff.String(), expected) {
 		t.Errorf("got %s Expected %s", buff.String(), expected)
 	}
@@ -1004,31 +1003,30 @@ func TestWrappedError(t *testing.T) {
 }
 
 func TestRemoveHandler(t *testing.T) {
+	SetDefault(New())
 	SetExitFunc(func(int) {})
 	SetWithErrorFn(errorsWithError)
-	logFields = logFields[0:0]
 	buff := new(bytes.Buffer)
 	th := &testHandler{
 		writer: buff,
 	}
-	logHandlers = map[Level][]Handler{}
 	AddHandler(th, InfoLevel)
 	RemoveHandler(th)
-	if len(logHandlers) != 0 {
+	if len(Default().load().handlers) != 0 {
 		t.Error("expected 0 handlers")
 	}
 
 	AddHandler(th, AllLevels...)
 	RemoveHandler(th)
-	if len(logHandlers) != 0 {
+	if len(Default().load().handlers) != 0 {
 		t.Error("expected 0 handlers")
 	}
 }
 
 func TestRemoveHandlerLevels(t *testing.T) {
+	SetDefault(New())
 	SetExitFunc(func(int) {})
 	SetWithErrorFn(errorsWithError)
-	logFields = logFields[0:0]
 	buff := new(bytes.Buffer)
 	th := &testHandler{
 		writer: buff,
@@ -1036,34 +1034,33 @@ func TestRemoveHandlerLevels(t *testing.T) {
 	th2 := &testHandler{
 		writer: buff,
 	}
-	logHandlers = map[Level][]Handler{}
 	AddHandler(th, InfoLevel)
-	removeHandlerLevels(th, InfoLevel)
-	if len(logHandlers) != 0 {
+	Default().removeHandlerLevels(th, InfoLevel)
+	if len(Default().load().handlers) != 0 {
 		t.Error("expected 0 handlers")
 	}
 
 	AddHandler(th, InfoLevel)
 	AddHandler(th2, InfoLevel)
-	removeHandlerLevels(th, InfoLevel)
-	if len(logHandlers) != 1 {
+	Default().removeHandlerLevels(th, InfoLevel)
+	if len(Default().load().handlers) != 1 {
 		t.Error("expected 1 handlers left")
 	}
-	if len(logHandlers[InfoLevel]) != 1 {
+	if len(Default().load().handlers[InfoLevel]) != 1 {
 		t.Error("expected 1 handlers with InfoLevel left")
 	}
-	removeHandlerLevels(th2, InfoLevel)
-	if len(logHandlers) != 0 {
+	Default().removeHandlerLevels(th2, InfoLevel)
+	if len(Default().load().handlers) != 0 {
 		t.Error("expected 0 handlers")
 	}
 
 	AddHandler(th, AllLevels...)
-	removeHandlerLevels(th, DebugLevel)
-	if len(logHandlers) != 7 {
+	Default().removeHandlerLevels(th, DebugLevel)
+	if len(Default().load().handlers) != 7 {
 		t.Error("expected 7 log levels left")
 	}
 
-	for _, handlers := range logHandlers {
+	for _, handlers := range Default().load().handlers {
 		if len(handlers) != 1 {
 			t.Error("expected 1 handlers for log level")
 		}
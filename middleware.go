@@ -0,0 +1,68 @@
+package log
+
+// Middleware is called with each entry before it is passed to handlers. It returns the, possibly
+// modified, entry and whether it should continue to be logged. Middleware must not modify the
+// Fields slice in place as it may be shared with other entries, use WithField(s) or copy it instead.
+type Middleware func(Entry) (Entry, bool)
+
+// Use appends middleware to the Default Instance's chain.
+// see Instance.Use for details.
+func Use(mw ...Middleware) {
+	Default().Use(mw...)
+}
+
+// UseFor appends middleware to the chain of a handler registered with the Default Instance.
+// see Instance.UseFor for details.
+func UseFor(h Handler, mw ...Middleware) {
+	Default().UseFor(h, mw...)
+}
+
+// Use appends middleware to the chain run, in order, for every entry before it is passed to any handler.
+// Any middleware returning false drops the entry and stops the chain.
+func (l *Instance) Use(mw ...Middleware) {
+	l.update(func(s *snapshot) {
+		s.middleware = appendMiddleware(s.middleware, mw)
+	})
+}
+
+// UseFor appends middleware to the chain run, in order, for every entry before it is passed to the
+// provided handler only, after the chain registered using Use. The chain is removed along with the handler.
+// It has no effect for handlers whose dynamic type is not comparable, as they cannot be identified.
+func (l *Instance) UseFor(h Handler, mw ...Middleware) {
+	if !sameHandler(h, h) {
+		return
+	}
+	l.update(func(s *snapshot) {
+		for i, c := range s.handlerMiddleware {
+			if sameHandler(c.h, h) {
+				s.handlerMiddleware[i].chain = appendMiddleware(c.chain, mw)
+				return
+			}
+		}
+		s.handlerMiddleware = append(s.handlerMiddleware, handlerChain{h: h, chain: appendMiddleware(nil, mw)})
+	})
+}
+
+// handlerChain is the middleware chain of a handler added using UseFor.
+type handlerChain struct {
+	h     Handler
+	chain []Middleware
+}
+
+// appendMiddleware appends to a copy so that snapshots already in use are never modified.
+func appendMiddleware(chain []Middleware, mw []Middleware) []Middleware {
+	c := make([]Middleware, len(chain), len(chain)+len(mw))
+	copy(c, chain)
+	return append(c, mw...)
+}
+
+// applyMiddleware runs the entry through the chain, returning false if it was dropped.
+func applyMiddleware(chain []Middleware, e Entry) (Entry, bool) {
+	for _, mw := range chain {
+		var ok bool
+		if e, ok = mw(e); !ok {
+			return e, false
+		}
+	}
+	return e, true
+}
@@ -0,0 +1,76 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	l := New()
+	all := new(bytes.Buffer)
+	filtered := new(bytes.Buffer)
+	allHandler := &testHandler{writer: all}
+	filteredHandler := &testHandler{writer: filtered}
+	l.AddHandler(allHandler, AllLevels...)
+	l.AddHandler(filteredHandler, AllLevels...)
+
+	l.Use(func(e Entry) (Entry, bool) {
+		return e.WithField("enriched", true), true
+	}, func(e Entry) (Entry, bool) {
+		return e, e.Message != "drop"
+	})
+	l.UseFor(filteredHandler, func(e Entry) (Entry, bool) {
+		return e, e.Level >= WarnLevel
+	})
+
+	l.Info("info")
+	l.Warn("drop")
+	l.Warn("warn")
+
+	if all.String() != "INFO info enriched=true\nWARN warn enriched=true\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO info enriched=true\nWARN warn enriched=true\n", all.String())
+	}
+	if filtered.String() != "WARN warn enriched=true\n" {
+		t.Errorf("Expected '%s' Got '%s'", "WARN warn enriched=true\n", filtered.String())
+	}
+
+	l.RemoveHandler(filteredHandler)
+	if len(l.load().handlerMiddleware) != 0 {
+		t.Error("Expected handler middleware to be removed with the handler")
+	}
+}
+
+// valueHandler is a handler whose dynamic type is not comparable.
+type valueHandler struct {
+	entries *[]string
+	_       []byte
+}
+
+func (h valueHandler) Log(e Entry) {
+	*h.entries = append(*h.entries, e.Message)
+}
+
+func TestMiddlewareNonComparableHandler(t *testing.T) {
+	l := New()
+	var entries []string
+	h := valueHandler{entries: &entries}
+	filtered := new(bytes.Buffer)
+	filteredHandler := &testHandler{writer: filtered}
+	l.AddHandler(h, AllLevels...)
+	l.AddHandler(filteredHandler, AllLevels...)
+	l.UseFor(filteredHandler, func(e Entry) (Entry, bool) {
+		return e, e.Level >= WarnLevel
+	})
+	l.UseFor(h, func(e Entry) (Entry, bool) {
+		return e, false
+	})
+
+	l.Info("info")
+	l.Warn("warn")
+	if len(entries) != 2 || entries[0] != "info" || entries[1] != "warn" {
+		t.Errorf("Expected '[info warn]' Got '%v'", entries)
+	}
+	if filtered.String() != "WARN warn\n" {
+		t.Errorf("Expected '%s' Got '%s'", "WARN warn\n", filtered.String())
+	}
+}
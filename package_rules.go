@@ -0,0 +1,147 @@
+package log
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// PackageRule sets the minimum level of entries logged from packages matching Pattern.
+//
+// Pattern is matched against the full import path of the package of the function calling the level
+// method eg. github.com/ourco/billing/invoice. A * matches any sequence of characters other than /, a ?
+// any single character other than / and ... any sequence of characters including /. As with the go
+// command a trailing /... also matches the package itself, so github.com/ourco/billing/... matches
+// github.com/ourco/billing and all packages beneath it.
+type PackageRule struct {
+	Pattern  string
+	MinLevel Level
+}
+
+// packageRules are the package rules of a snapshot along with the index of the rule matching each call
+// site, resolved the first time an entry is logged from it.
+type packageRules struct {
+	rules []PackageRule
+	// cache maps each call site PC to the index of the first matching rule, or -1 when none match.
+	cache sync.Map
+}
+
+// SetPackageRules sets the rules filtering entries of the Default Instance by the package logging them.
+// see Instance.SetPackageRules for details.
+func SetPackageRules(rules ...PackageRule) error {
+	return Default().SetPackageRules(rules...)
+}
+
+// SetPackageRules sets rules filtering entries by the package of the function logging them, replacing
+// any previously set. The first rule matching the package determines the minimum level, entries from
+// packages not matching any rule are unaffected. Call with no rules to disable.
+//
+// Rules only filter entries, handlers must still be registered for the levels. To log Debug entries
+// from a single package register the handlers for DebugLevel and add a catch-all rule, eg.
+//
+//	log.SetPackageRules(
+//		log.PackageRule{Pattern: "github.com/ourco/billing/...", MinLevel: log.DebugLevel},
+//		log.PackageRule{Pattern: "...", MinLevel: log.InfoLevel},
+//	)
+//
+// Rules apply to entries logged using the level methods, the package of each call site is resolved once
+// and cached, and not to entries passed directly to HandleEntry such as those redirected from slog.
+func (l *Instance) SetPackageRules(rules ...PackageRule) error {
+	for _, r := range rules {
+		if r.Pattern == "" {
+			return errors.New("log: package rule pattern must not be empty")
+		}
+	}
+	var p *packageRules
+	if len(rules) > 0 {
+		p = &packageRules{rules: append([]PackageRule(nil), rules...)}
+	}
+	l.update(func(s *snapshot) {
+		s.packages = p
+	})
+	return nil
+}
+
+// enabledFrom returns if at least one handler is registered for the level, or it is buffered by the
+// flight recording, and it is allowed by the package rules for the function skip frames above the caller.
+func (l *Instance) enabledFrom(level Level, skip int, rec *flightRecording) bool {
+	s := l.load()
+	if !s.enabledFor(level) && (rec == nil || !rec.recorder.levels.has(level)) {
+		return false
+	}
+	if s.packages == nil {
+		return true
+	}
+	var pc [1]uintptr
+	runtime.Callers(3+skip, pc[:])
+	return s.packages.allowed(pc[0], level)
+}
+
+// allowed returns if the level is allowed for the call site.
+func (p *packageRules) allowed(pc uintptr, level Level) bool {
+	var i int
+	if v, found := p.cache.Load(pc); found {
+		i = v.(int)
+	} else {
+		i = p.resolve(pc)
+	}
+	return i < 0 || level.Severity() >= p.rules[i].MinLevel.Severity()
+}
+
+// resolve finds the first rule matching the package of the call site and caches it.
+func (p *packageRules) resolve(pc uintptr) int {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	pkg, _, _ := splitFunction(frame.Function)
+	// the runtime escapes dots in the last element of the package path
+	pkg = strings.ReplaceAll(pkg, "%2e", ".")
+	i := -1
+	for j, r := range p.rules {
+		if matchPackage(r.Pattern, pkg) {
+			i = j
+			break
+		}
+	}
+	p.cache.Store(pc, i)
+	return i
+}
+
+// matchPackage reports whether the package path matches the PackageRule pattern.
+func matchPackage(pattern, pkg string) bool {
+	if strings.HasSuffix(pattern, "/...") && matchPackage(pattern[:len(pattern)-4], pkg) {
+		return true
+	}
+	for len(pattern) > 0 {
+		switch {
+		case strings.HasPrefix(pattern, "..."):
+			pattern = pattern[3:]
+			for i := 0; i <= len(pkg); i++ {
+				if matchPackage(pattern, pkg[i:]) {
+					return true
+				}
+			}
+			return false
+		case pattern[0] == '*':
+			pattern = pattern[1:]
+			for i := 0; i <= len(pkg); i++ {
+				if matchPackage(pattern, pkg[i:]) {
+					return true
+				}
+				if i < len(pkg) && pkg[i] == '/' {
+					break
+				}
+			}
+			return false
+		case len(pkg) == 0:
+			return false
+		case pattern[0] == '?':
+			if pkg[0] == '/' {
+				return false
+			}
+		case pattern[0] != pkg[0]:
+			return false
+		}
+		pattern, pkg = pattern[1:], pkg[1:]
+	}
+	return len(pkg) == 0
+}
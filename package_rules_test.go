@@ -0,0 +1,126 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestMatchPackage(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		pkg      string
+		expected bool
+	}{
+		{"github.com/ourco/billing", "github.com/ourco/billing", true},
+		{"github.com/ourco/billing", "github.com/ourco/billing/invoice", false},
+		{"github.com/ourco/billing/...", "github.com/ourco/billing", true},
+		{"github.com/ourco/billing/...", "github.com/ourco/billing/invoice/tax", true},
+		{"github.com/ourco/billing/...", "github.com/ourco/billingv2", false},
+		{"github.com/ourco/*", "github.com/ourco/billing", true},
+		{"github.com/ourco/*", "github.com/ourco/billing/invoice", false},
+		{"github.com/ourco/*/invoice", "github.com/ourco/billing/invoice", true},
+		{"github.com/ourco/bill?ng", "github.com/ourco/billing", true},
+		{"github.com/ourco?billing", "github.com/ourco/billing", false},
+		{".../invoice", "github.com/ourco/billing/invoice", true},
+		{".../invoice", "github.com/ourco/billing/invoices", false},
+		{"...", "main", true},
+		{"*", "github.com/ourco/billing", false},
+	}
+	for _, tt := range tests {
+		if got := matchPackage(tt.pattern, tt.pkg); got != tt.expected {
+			t.Errorf("matchPackage(%q, %q) expected '%t' Got '%t'", tt.pattern, tt.pkg, tt.expected, got)
+		}
+	}
+}
+
+func TestSplitFunction(t *testing.T) {
+	tests := []struct {
+		function string
+		pkg      string
+		funcName string
+		ok       bool
+	}{
+		{"github.com/go-playground/log/v8.(*Entry).Info", "github.com/go-playground/log/v8", "Info", true},
+		{"github.com/go-playground/log/v8.TestSplitFunction.func1", "github.com/go-playground/log/v8", "func1", true},
+		{"main.main", "main", "main", true},
+		{"gopkg.in/yaml%2ev3.Unmarshal", "gopkg.in/yaml%2ev3", "Unmarshal", true},
+		{"unknown", "", "", false},
+	}
+	for _, tt := range tests {
+		pkg, funcName, ok := splitFunction(tt.function)
+		if pkg != tt.pkg || funcName != tt.funcName || ok != tt.ok {
+			t.Errorf("splitFunction(%q) expected '%s' '%s' '%t' Got '%s' '%s' '%t'", tt.function, tt.pkg, tt.funcName, tt.ok, pkg, funcName, ok)
+		}
+	}
+}
+
+func TestPackageRules(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(&testHandler{writer: buff}, AllLevels...)
+	defer SetDefault(Default())
+	SetDefault(l)
+
+	if err := l.SetPackageRules(PackageRule{Pattern: "", MinLevel: InfoLevel}); err == nil {
+		t.Fatal("Expected error for empty pattern")
+	}
+	err := l.SetPackageRules(
+		PackageRule{Pattern: "github.com/ourco/...", MinLevel: DebugLevel},
+		PackageRule{Pattern: "github.com/go-playground/log/v8", MinLevel: WarnLevel},
+		PackageRule{Pattern: "...", MinLevel: DebugLevel},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error '%s'", err)
+	}
+
+	l.Debug("debug")
+	l.WithField("key", "value").Info("info")
+	l.Acquire().Info("pooled info")
+	l.Warn("warn")
+	l.Acquire().Error("pooled error")
+	Info("package info")
+	Error("package error")
+	if expected := "WARN warn\nERROR pooled error\nERROR package error\n"; buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	// every call site is resolved to this function rather than the log methods
+	l.load().packages.cache.Range(func(pc, i interface{}) bool {
+		frame, _ := runtime.CallersFrames([]uintptr{pc.(uintptr)}).Next()
+		if !strings.HasSuffix(frame.Function, ".TestPackageRules") || i != 1 {
+			t.Errorf("Expected call site in TestPackageRules matching rule 1 Got '%s' rule %v", frame.Function, i)
+		}
+		return true
+	})
+
+	buff.Reset()
+	if err = l.SetPackageRules(); err != nil {
+		t.Fatalf("Unexpected error '%s'", err)
+	}
+	l.Debug("debug")
+	if expected := "DEBUG debug\n"; buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+func TestPackageRulesNoAlloc(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not stable with the race detector enabled")
+	}
+	l := New()
+	l.AddHandler(NewConsoleBuilder().WithWriter(io.Discard).Build(), AllLevels...)
+	if err := l.SetPackageRules(PackageRule{Pattern: "...", MinLevel: InfoLevel}); err != nil {
+		t.Fatalf("Unexpected error '%s'", err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		l.Acquire().With(String("string", "value")).Info("pooled")
+		l.Acquire().With(String("string", "value")).Debug("filtered")
+	})
+	if allocs != 0 {
+		t.Errorf("Expected 0 allocations Got %v", allocs)
+	}
+}
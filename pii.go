@@ -0,0 +1,324 @@
+package log
+
+import (
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Detector finds personally identifiable information within a string.
+type Detector interface {
+	// Detect returns the [start, end) byte offsets of each occurrence found in s, or nil if there are none.
+	// It is called for every message and string field value so should return quickly when s cannot contain a match.
+	Detect(s string) [][]int
+}
+
+// DetectorFunc allows an ordinary function to be used as a Detector.
+type DetectorFunc func(s string) [][]int
+
+// Detect calls f(s).
+func (f DetectorFunc) Detect(s string) [][]int {
+	return f(s)
+}
+
+var (
+	emailRegex  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	cardRegex   = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+	ipv4Regex   = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	jwtRegex    = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	bearerRegex = regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`)
+)
+
+// Built-in detectors.
+var (
+	// EmailDetector detects email addresses.
+	EmailDetector Detector = DetectorFunc(detectEmail)
+	// CreditCardDetector detects 13 to 19 digit card numbers, optionally separated by spaces or dashes,
+	// that pass the Luhn checksum.
+	CreditCardDetector Detector = DetectorFunc(detectCreditCard)
+	// IPv4Detector detects IPv4 addresses.
+	IPv4Detector Detector = DetectorFunc(detectIPv4)
+	// IPv6Detector detects IPv6 addresses.
+	IPv6Detector Detector = DetectorFunc(detectIPv6)
+	// JWTDetector detects JSON Web Tokens.
+	JWTDetector Detector = DetectorFunc(detectJWT)
+	// BearerTokenDetector detects the token following "Bearer", leaving the scheme in place.
+	BearerTokenDetector Detector = DetectorFunc(detectBearer)
+)
+
+// DefaultDetectors returns all of the built-in detectors.
+func DefaultDetectors() []Detector {
+	return []Detector{
+		EmailDetector,
+		CreditCardDetector,
+		IPv4Detector,
+		IPv6Detector,
+		JWTDetector,
+		BearerTokenDetector,
+	}
+}
+
+func detectEmail(s string) [][]int {
+	if strings.IndexByte(s, '@') == -1 {
+		return nil
+	}
+	return emailRegex.FindAllStringIndex(s, -1)
+}
+
+func detectCreditCard(s string) [][]int {
+	var digits int
+	for i := 0; i < len(s) && digits < 13; i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits++
+		}
+	}
+	if digits < 13 {
+		return nil
+	}
+	var found [][]int
+	for _, loc := range cardRegex.FindAllStringIndex(s, -1) {
+		if luhn(s[loc[0]:loc[1]]) {
+			found = append(found, loc)
+		}
+	}
+	return found
+}
+
+// luhn validates the Luhn checksum of the digits in s, ignoring separators.
+func luhn(s string) bool {
+	var sum, n int
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+		n++
+	}
+	return n >= 13 && n <= 19 && sum%10 == 0
+}
+
+func detectIPv4(s string) [][]int {
+	if strings.Count(s, ".") < 3 {
+		return nil
+	}
+	var found [][]int
+	for _, loc := range ipv4Regex.FindAllStringIndex(s, -1) {
+		if net.ParseIP(s[loc[0]:loc[1]]) != nil {
+			found = append(found, loc)
+		}
+	}
+	return found
+}
+
+func detectIPv6(s string) [][]int {
+	if strings.Count(s, ":") < 2 {
+		return nil
+	}
+	var found [][]int
+	for i := 0; i < len(s); {
+		if !isIPv6Char(s[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && isIPv6Char(s[i]) {
+			i++
+		}
+		// trailing dots are more likely punctuation than part of an IPv4-mapped address
+		end := i
+		for end > start && s[end-1] == '.' {
+			end--
+		}
+		// addresses must be whole words so that eg. the Abc:: of Abc::Method is not matched
+		if start > 0 && isAlphanumeric(s[start-1]) || end < len(s) && isAlphanumeric(s[end]) {
+			continue
+		}
+		candidate := s[start:end]
+		if strings.Count(candidate, ":") >= 2 && net.ParseIP(candidate) != nil {
+			found = append(found, []int{start, end})
+		}
+	}
+	return found
+}
+
+func isIPv6Char(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F' || c == ':' || c == '.'
+}
+
+func isAlphanumeric(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func detectJWT(s string) [][]int {
+	if !strings.Contains(s, "eyJ") {
+		return nil
+	}
+	return jwtRegex.FindAllStringIndex(s, -1)
+}
+
+func detectBearer(s string) [][]int {
+	if !containsFold(s, "bearer") {
+		return nil
+	}
+	matches := bearerRegex.FindAllStringSubmatchIndex(s, -1)
+	found := make([][]int, 0, len(matches))
+	for _, m := range matches {
+		found = append(found, m[2:4])
+	}
+	return found
+}
+
+// containsFold reports whether the lowercase ASCII substr is within s, ignoring case, without allocating.
+func containsFold(s, substr string) bool {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i]|0x20 == substr[0] && strings.EqualFold(s[i:i+len(substr)], substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// PIIScannerBuilder is used to configure and create a new PIIScanner
+type PIIScannerBuilder struct {
+	mask      string
+	detectors []Detector
+}
+
+// NewPIIScannerBuilder creates a new PIIScannerBuilder using the DefaultDetectors.
+func NewPIIScannerBuilder() *PIIScannerBuilder {
+	return &PIIScannerBuilder{
+		mask:      DefaultMask,
+		detectors: DefaultDetectors(),
+	}
+}
+
+// WithMask sets the replacement for detected values.
+func (b *PIIScannerBuilder) WithMask(mask string) *PIIScannerBuilder {
+	b.mask = mask
+	return b
+}
+
+// WithDetectors replaces the detectors used.
+func (b *PIIScannerBuilder) WithDetectors(detectors ...Detector) *PIIScannerBuilder {
+	b.detectors = append([]Detector(nil), detectors...)
+	return b
+}
+
+// AddDetectors adds custom detectors to those used.
+func (b *PIIScannerBuilder) AddDetectors(detectors ...Detector) *PIIScannerBuilder {
+	b.detectors = append(b.detectors, detectors...)
+	return b
+}
+
+// Build creates a new PIIScanner.
+func (b *PIIScannerBuilder) Build() *PIIScanner {
+	return &PIIScanner{
+		mask:      b.mask,
+		detectors: append([]Detector(nil), b.detectors...),
+	}
+}
+
+// PIIScanner masks personally identifiable information detected in entry messages and string field values.
+type PIIScanner struct {
+	mask      string
+	detectors []Detector
+}
+
+// SetPIIScanner sets the PIIScanner of the Default Instance.
+// see Instance.SetPIIScanner for details.
+func SetPIIScanner(p *PIIScanner) {
+	Default().SetPIIScanner(p)
+}
+
+// SetPIIScanner sets the PIIScanner applied to every entry, after any Redactor, before it is passed to
+// any handler. Passing nil disables scanning.
+func (l *Instance) SetPIIScanner(p *PIIScanner) {
+	l.update(func(s *snapshot) {
+		s.piiScanner = p
+	})
+}
+
+// Scan returns the entry with any detected PII in its message and string field values masked.
+// The original entry's fields are never modified.
+func (p *PIIScanner) Scan(e Entry) Entry {
+	e.Message = p.MaskString(e.Message)
+	if fields, changed := p.scanFields(e.Fields); changed {
+		e.Fields = fields
+	}
+	return e
+}
+
+// Middleware allows the PIIScanner to be registered using Use.
+func (p *PIIScanner) Middleware(e Entry) (Entry, bool) {
+	return p.Scan(e), true
+}
+
+// MaskString returns s with any detected PII masked, s is returned as is when nothing is detected.
+func (p *PIIScanner) MaskString(s string) string {
+	var found [][]int
+	for _, d := range p.detectors {
+		found = append(found, d.Detect(s)...)
+	}
+	if len(found) == 0 {
+		return s
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i][0] < found[j][0]
+	})
+
+	var b strings.Builder
+	b.Grow(len(s))
+	var last int
+	for _, loc := range found {
+		if loc[1] <= last {
+			continue // contained within a previous match
+		}
+		// matches overlapping a previous one extend its mask rather than adding another
+		if loc[0] >= last {
+			b.WriteString(s[last:loc[0]])
+			b.WriteString(p.mask)
+		}
+		last = loc[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// scanFields returns the masked fields, copying them if any were changed.
+func (p *PIIScanner) scanFields(fields []Field) ([]Field, bool) {
+	var scanned []Field
+	for i, f := range fields {
+		var changed bool
+		if group, ok := f.Value.([]Field); ok {
+			if group, c := p.scanFields(group); c {
+				f.Value, changed = group, true
+			}
+		} else if s, ok := f.stringValue(); ok {
+			if masked := p.MaskString(s); masked != s {
+				f, changed = Field{Key: f.Key, Value: masked}, true
+			}
+		}
+		if scanned == nil {
+			if !changed {
+				continue
+			}
+			scanned = make([]Field, i, len(fields))
+			copy(scanned, fields[:i])
+		}
+		scanned = append(scanned, f)
+	}
+	if scanned == nil {
+		return fields, false
+	}
+	return scanned, true
+}
@@ -0,0 +1,120 @@
+package log
+
+import "sync"
+
+var pooledEntries = sync.Pool{
+	New: func() interface{} {
+		return &PooledEntry{
+			fields: make([]Field, 0, 16),
+		}
+	},
+}
+
+// PooledEntry is an opt-in allocation free alternative to Entry. Its field slice is reused between
+// entries, so combined with the typed field constructors and handlers that do not retain entries, such as
+// the console and json handlers, logging does not allocate.
+//
+// A PooledEntry is returned to the pool once one of its level methods is called, or Release, and must
+// not be used afterwards. Handlers that retain entries beyond their Log call must copy the entry's
+// fields, AsyncHandler and FlightRecorder do so automatically.
+type PooledEntry struct {
+	l      *Instance
+	fields []Field
+}
+
+// Acquire returns a PooledEntry from the Default Instance.
+// see Instance.Acquire for details.
+func Acquire() *PooledEntry {
+	return Default().Acquire()
+}
+
+// Acquire returns a PooledEntry containing this Instance's default fields.
+func (l *Instance) Acquire() *PooledEntry {
+	p := pooledEntries.Get().(*PooledEntry)
+	p.l = l
+	p.fields = append(p.fields, l.fields...)
+	return p
+}
+
+// With adds the supplied fields to the entry.
+func (p *PooledEntry) With(fields ...Field) *PooledEntry {
+	p.fields = append(p.fields, fields...)
+	return p
+}
+
+// Release returns the entry to the pool without logging it.
+func (p *PooledEntry) Release() {
+	for i := range p.fields {
+		p.fields[i] = Field{}
+	}
+	p.fields = p.fields[:0]
+	p.l = nil
+	pooledEntries.Put(p)
+}
+
+// Log logs an entry at the provided level and releases it.
+// see Entry.Log for details.
+func (p *PooledEntry) Log(level Level, msg string) {
+	p.log(level, msg)
+}
+
+// Debug logs a debug entry and releases it.
+func (p *PooledEntry) Debug(msg string) {
+	p.log(DebugLevel, msg)
+}
+
+// Info logs a normal. information, entry and releases it.
+func (p *PooledEntry) Info(msg string) {
+	p.log(InfoLevel, msg)
+}
+
+// Notice logs a notice log entry and releases it.
+func (p *PooledEntry) Notice(msg string) {
+	p.log(NoticeLevel, msg)
+}
+
+// Warn logs a warning log entry and releases it.
+func (p *PooledEntry) Warn(msg string) {
+	p.log(WarnLevel, msg)
+}
+
+// Error logs an error log entry and releases it.
+func (p *PooledEntry) Error(msg string) {
+	p.log(ErrorLevel, msg)
+}
+
+// Alert logs an alert log entry and releases it.
+func (p *PooledEntry) Alert(msg string) {
+	p.log(AlertLevel, msg)
+}
+
+// Panic logs a panic log entry, releases it and calls the exit function.
+func (p *PooledEntry) Panic(msg string) {
+	l := p.l
+	p.log(PanicLevel, msg)
+	l.exit(1)
+}
+
+// Fatal logs a fatal log entry, releases it and calls the exit function.
+func (p *PooledEntry) Fatal(msg string) {
+	l := p.l
+	p.log(FatalLevel, msg)
+	l.exit(1)
+}
+
+func (p *PooledEntry) log(level Level, msg string) {
+	l := p.l
+	if l.enabledFrom(level, 1, nil) {
+		e := Entry{
+			Message:    msg,
+			Level:      level,
+			Fields:     p.fields,
+			instance:   l,
+			callerSkip: 1,
+			pooled:     true,
+		}
+		e.addSource(l)
+		l.HandleEntry(e)
+	}
+	p.Release()
+}
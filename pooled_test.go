@@ -0,0 +1,94 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPooledEntry(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(&testHandler{writer: buff}, InfoLevel)
+	l.WithDefaultFields(F("app", "test"))
+
+	l.Acquire().With(String("key", "value"), Int64("count", 2)).Info("pooled")
+	l.Acquire().With(String("other", "value")).Info("reused")
+	l.Acquire().With(String("skipped", "value")).Debug("disabled")
+	l.Acquire().Release()
+
+	expected := "INFO pooled app=test key=value count=2\nINFO reused app=test other=value\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+func TestPooledEntryCaller(t *testing.T) {
+	l := New()
+	h := new(callerHandler)
+	l.AddHandler(h, InfoLevel)
+	l.SetCallerLevels(InfoLevel)
+
+	l.Acquire().Info("caller")
+	if len(h.callers) != 1 || !strings.HasSuffix(h.callers[0], "pooled_test.go:34:TestPooledEntryCaller") {
+		t.Errorf("Expected caller of this test Got '%v'", h.callers)
+	}
+}
+
+type gatedHandler struct {
+	release chan struct{}
+	fieldsHandler
+}
+
+func (h *gatedHandler) Log(e Entry) {
+	<-h.release
+	h.fieldsHandler.Log(e)
+}
+
+func TestPooledEntryAsync(t *testing.T) {
+	l := New()
+	h := &gatedHandler{release: make(chan struct{})}
+	a := NewAsyncBuilder().Build(h)
+	l.AddHandler(a, InfoLevel)
+
+	buf := []byte("original")
+	l.Acquire().With(String("key", "first"), Bytes("bytes", buf)).Info("one")
+	l.Acquire().With(String("key", "second")).Info("two")
+	copy(buf, "MUTATED!")
+	close(h.release)
+	if err := a.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(h.entries) != 2 || h.entries[0].Fields[0].StringValue() != "first" || h.entries[1].Fields[0].StringValue() != "second" {
+		t.Errorf("Expected queued entries to keep their own fields Got '%v'", h.entries)
+	}
+	if v := h.entries[0].Fields[1].StringValue(); v != "original" {
+		t.Errorf("Expected bytes to be copied Got '%s'", v)
+	}
+}
+
+var raceEnabled bool
+
+func TestPooledEntryNoAlloc(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not stable with the race detector enabled")
+	}
+	l := New()
+	l.AddHandler(NewConsoleBuilder().WithWriter(io.Discard).Build(), AllLevels...)
+	err := errors.New("failed")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		l.Acquire().With(
+			String("string", "value"),
+			Int64("int64", 1),
+			Bool("bool", true),
+			Err(err),
+		).Info("pooled")
+	})
+	if allocs != 0 {
+		t.Errorf("Expected 0 allocations Got %v", allocs)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build race
+// +build race
+
+package log
+
+func init() {
+	// sync.Pool randomly drops items when the race detector is enabled
+	raceEnabled = true
+}
@@ -0,0 +1,235 @@
+package log
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// RedactMode is how a sensitive value is redacted.
+type RedactMode uint8
+
+// Redaction modes.
+const (
+	// RedactMask replaces the value, or the matched portion for value patterns, with the mask.
+	RedactMask RedactMode = iota
+	// RedactHash replaces the value, or the matched portion for value patterns, with a truncated sha256
+	// hash allowing values to be correlated without being revealed.
+	RedactHash
+	// RedactRemove removes the field entirely.
+	RedactRemove
+)
+
+// DefaultMask is the default replacement for masked values.
+const DefaultMask = "[REDACTED]"
+
+type keyRule struct {
+	name    string
+	pattern string
+	path    string
+	mode    RedactMode
+}
+
+type valueRule struct {
+	re   *regexp.Regexp
+	mode RedactMode
+}
+
+// RedactorBuilder is used to configure and create a new Redactor
+type RedactorBuilder struct {
+	mask   string
+	keys   []keyRule
+	values []valueRule
+}
+
+// NewRedactorBuilder creates a new RedactorBuilder for configuring and creating a new Redactor
+func NewRedactorBuilder() *RedactorBuilder {
+	return &RedactorBuilder{
+		mask: DefaultMask,
+	}
+}
+
+// WithMask sets the replacement used by RedactMask.
+func (b *RedactorBuilder) WithMask(mask string) *RedactorBuilder {
+	b.mask = mask
+	return b
+}
+
+// WithKeys redacts fields, at any group depth, whose key case-insensitively equals one of the names.
+func (b *RedactorBuilder) WithKeys(mode RedactMode, names ...string) *RedactorBuilder {
+	for _, name := range names {
+		b.keys = append(b.keys, keyRule{name: strings.ToLower(name), mode: mode})
+	}
+	return b
+}
+
+// WithKeyPatterns redacts fields, at any group depth, whose key case-insensitively matches one of the
+// glob patterns, using path.Match syntax eg. "*_token".
+func (b *RedactorBuilder) WithKeyPatterns(mode RedactMode, patterns ...string) *RedactorBuilder {
+	for _, pattern := range patterns {
+		b.keys = append(b.keys, keyRule{pattern: strings.ToLower(pattern), mode: mode})
+	}
+	return b
+}
+
+// WithPaths redacts fields by their case-insensitive dot separated path through groups created using G,
+// eg. "request.headers.authorization". Each path may be a glob pattern using path.Match syntax.
+func (b *RedactorBuilder) WithPaths(mode RedactMode, paths ...string) *RedactorBuilder {
+	for _, p := range paths {
+		b.keys = append(b.keys, keyRule{path: strings.ToLower(p), mode: mode})
+	}
+	return b
+}
+
+// WithValuePatterns redacts the portions of string field values, at any group depth, matching one of
+// the regular expressions. RedactRemove removes the field when any portion matches.
+func (b *RedactorBuilder) WithValuePatterns(mode RedactMode, patterns ...*regexp.Regexp) *RedactorBuilder {
+	for _, re := range patterns {
+		b.values = append(b.values, valueRule{re: re, mode: mode})
+	}
+	return b
+}
+
+// Build creates a new Redactor from the configured rules.
+func (b *RedactorBuilder) Build() *Redactor {
+	r := &Redactor{
+		mask:   b.mask,
+		keys:   make([]keyRule, len(b.keys)),
+		values: make([]valueRule, len(b.values)),
+	}
+	copy(r.keys, b.keys)
+	copy(r.values, b.values)
+	return r
+}
+
+// Redactor redacts sensitive fields from entries. Key based rules are checked in the order they were
+// added and take precedence over value patterns.
+type Redactor struct {
+	mask   string
+	keys   []keyRule
+	values []valueRule
+}
+
+// SetRedactor sets the Redactor of the Default Instance.
+// see Instance.SetRedactor for details.
+func SetRedactor(r *Redactor) {
+	Default().SetRedactor(r)
+}
+
+// SetRedactor sets the Redactor applied to every entry, after any middleware, before it is passed to any
+// handler including entries from the slog bridge. Passing nil disables redaction.
+func (l *Instance) SetRedactor(r *Redactor) {
+	l.update(func(s *snapshot) {
+		s.redactor = r
+	})
+}
+
+// Redact returns the entry with its sensitive fields redacted. The original entry's fields are never modified.
+func (r *Redactor) Redact(e Entry) Entry {
+	if fields, changed := r.redactFields("", e.Fields); changed {
+		e.Fields = fields
+	}
+	return e
+}
+
+// Middleware allows the Redactor to be registered using Use.
+func (r *Redactor) Middleware(e Entry) (Entry, bool) {
+	return r.Redact(e), true
+}
+
+// redactFields returns the redacted fields, copying them if any were changed.
+func (r *Redactor) redactFields(prefix string, fields []Field) ([]Field, bool) {
+	var redacted []Field
+	for i, f := range fields {
+		nf, keep, changed := r.redactField(prefix, f)
+		if !changed {
+			if redacted != nil {
+				redacted = append(redacted, f)
+			}
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]Field, i, len(fields))
+			copy(redacted, fields[:i])
+		}
+		if keep {
+			redacted = append(redacted, nf)
+		}
+	}
+	if redacted == nil {
+		return fields, false
+	}
+	return redacted, true
+}
+
+func (r *Redactor) redactField(prefix string, f Field) (nf Field, keep bool, changed bool) {
+	key := strings.ToLower(f.Key)
+	fullPath := prefix + key
+
+	for _, rule := range r.keys {
+		var matched bool
+		switch {
+		case rule.name != "":
+			matched = key == rule.name
+		case rule.pattern != "":
+			matched, _ = path.Match(rule.pattern, key)
+		default:
+			matched, _ = path.Match(rule.path, fullPath)
+		}
+		if matched {
+			return r.redactValue(f, rule.mode)
+		}
+	}
+
+	if group, ok := f.Value.([]Field); ok {
+		if fields, c := r.redactFields(fullPath+".", group); c {
+			return Field{Key: f.Key, Value: fields}, true, true
+		}
+	} else if s, ok := f.stringValue(); ok {
+		return r.redactString(f, s)
+	}
+	return f, true, false
+}
+
+func (r *Redactor) redactValue(f Field, mode RedactMode) (Field, bool, bool) {
+	switch mode {
+	case RedactRemove:
+		return f, false, true
+	case RedactHash:
+		if _, isGroup := f.Value.([]Field); !isGroup {
+			return Field{Key: f.Key, Value: hashValue(fmt.Sprint(f.Interface()))}, true, true
+		}
+	}
+	return Field{Key: f.Key, Value: r.mask}, true, true
+}
+
+func (r *Redactor) redactString(f Field, s string) (Field, bool, bool) {
+	var changed bool
+	for _, rule := range r.values {
+		if !rule.re.MatchString(s) {
+			continue
+		}
+		changed = true
+		switch rule.mode {
+		case RedactRemove:
+			return f, false, true
+		case RedactHash:
+			s = rule.re.ReplaceAllStringFunc(s, hashValue)
+		default:
+			s = rule.re.ReplaceAllLiteralString(s, r.mask)
+		}
+	}
+	if !changed {
+		return f, true, false
+	}
+	return Field{Key: f.Key, Value: s}, true, true
+}
+
+// hashValue returns the first 8 bytes of the sha256 hash of the value, hex encoded.
+func hashValue(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,85 @@
+package log
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	r := NewRedactorBuilder().
+		WithKeys(RedactMask, "Password").
+		WithKeyPatterns(RedactRemove, "*_secret").
+		WithPaths(RedactHash, "request.headers.authorization").
+		WithValuePatterns(RedactMask, regexp.MustCompile(`tok_[a-z0-9]+`)).
+		Build()
+
+	fields := []Field{
+		F("user", "joeybloggs"),
+		F("password", "hunter2"),
+		F("client_secret", "s3cr3t"),
+		G("request",
+			F("path", "/login?t=tok_abc123"),
+			G("headers", F("authorization", "Bearer x"), F("accept", "*/*")),
+			F("PASSWORD", 1234),
+		),
+		F("authorization", "not a path match"),
+	}
+	e := Entry{Fields: fields}
+	redacted := r.Redact(e)
+
+	expected := []Field{
+		F("user", "joeybloggs"),
+		F("password", DefaultMask),
+		G("request",
+			F("path", "/login?t="+DefaultMask),
+			G("headers", F("authorization", hashValue("Bearer x")), F("accept", "*/*")),
+			F("PASSWORD", DefaultMask),
+		),
+		F("authorization", "not a path match"),
+	}
+	assertFields(t, "", redacted.Fields, expected)
+
+	// original fields must be left untouched
+	if fields[1].Value != "hunter2" || fields[3].Value.([]Field)[0].Value != "/login?t=tok_abc123" {
+		t.Errorf("Expected original fields to be unmodified Got '%v'", fields)
+	}
+
+	unchanged := Entry{Fields: []Field{F("key", "value")}}
+	if r.Redact(unchanged).Fields[0] != unchanged.Fields[0] {
+		t.Error("Expected unchanged fields")
+	}
+}
+
+func TestSetRedactor(t *testing.T) {
+	l := New()
+	h := &fieldsHandler{}
+	l.AddHandler(h, AllLevels...)
+	l.Use(func(e Entry) (Entry, bool) {
+		return e.WithField("token", "added by middleware"), true
+	})
+	l.SetRedactor(NewRedactorBuilder().WithKeys(RedactRemove, "token").Build())
+	l.WithField("token", "abc").Info("info")
+	if len(h.entries) != 1 || len(h.entries[0].Fields) != 0 {
+		t.Errorf("Expected all tokens removed Got '%v'", h.entries)
+	}
+}
+
+func assertFields(t *testing.T, prefix string, got, expected []Field) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("%s: Expected '%v' Got '%v'", prefix, expected, got)
+	}
+	for i := range expected {
+		if got[i].Key != expected[i].Key {
+			t.Errorf("%s: Expected key '%s' Got '%s'", prefix, expected[i].Key, got[i].Key)
+			continue
+		}
+		if group, ok := expected[i].Value.([]Field); ok {
+			assertFields(t, prefix+got[i].Key+".", got[i].Value.([]Field), group)
+			continue
+		}
+		if got[i].Interface() != expected[i].Interface() {
+			t.Errorf("%s%s: Expected '%v' Got '%v'", prefix, got[i].Key, expected[i].Interface(), got[i].Interface())
+		}
+	}
+}
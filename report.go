@@ -0,0 +1,97 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultErrorInterval is the minimum time between reports written by the default error function.
+const defaultErrorInterval = 10 * time.Second
+
+// ReportError reports a failure by the handler to process the entry to the error function of the
+// Instance the entry was logged with. Handlers should call this rather than silently discarding errors.
+func ReportError(h Handler, e Entry, err error) {
+	e.logger().load().errorFunc(h, e, err)
+}
+
+// HandlerPanicError is reported to the error function when a handler panics while logging an entry.
+type HandlerPanicError struct {
+	// Handler is the handler that panicked.
+	Handler Handler
+	// Value is the value recovered from the panic.
+	Value interface{}
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Error returns the panic including the type of the handler that caused it.
+func (p *HandlerPanicError) Error() string {
+	return fmt.Sprintf("handler %T panicked: %v", p.Handler, p.Value)
+}
+
+// SetErrorFunc sets the function called when a handler of the Default Instance reports an error.
+// see Instance.SetErrorFunc for details.
+func SetErrorFunc(fn func(h Handler, e Entry, err error)) {
+	Default().SetErrorFunc(fn)
+}
+
+// SetErrorFunc sets the function called when a handler reports an error via ReportError.
+// By default errors are written to os.Stderr at most once every 10 seconds, passing nil restores the
+// default. It is safe to call while entries are being logged, including by asynchronous handlers.
+func (l *Instance) SetErrorFunc(fn func(h Handler, e Entry, err error)) {
+	if fn == nil {
+		fn = NewErrorWriter(os.Stderr, defaultErrorInterval)
+	}
+	l.update(func(s *snapshot) {
+		s.errorFunc = fn
+	})
+}
+
+// NewErrorWriter returns an error function, for use with SetErrorFunc, that writes errors to the
+// supplied writer at most once per interval. The number of errors suppressed in between is included
+// in the next report.
+func NewErrorWriter(w io.Writer, interval time.Duration) func(h Handler, e Entry, err error) {
+	r := &errorWriter{
+		writer:   w,
+		interval: interval,
+	}
+	return r.report
+}
+
+type errorWriter struct {
+	m          sync.Mutex
+	writer     io.Writer
+	interval   time.Duration
+	last       time.Time
+	suppressed uint64
+}
+
+func (r *errorWriter) report(h Handler, e Entry, err error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	now := time.Now()
+	if !r.last.IsZero() && now.Sub(r.last) < r.interval {
+		r.suppressed++
+		return
+	}
+	r.last = now
+
+	buff := BytePool().Get()
+	buff.B = append(buff.B, "log: handler "...)
+	buff.B = append(buff.B, fmt.Sprintf("%T", h)...)
+	buff.B = append(buff.B, " failed to log "...)
+	buff.B = append(buff.B, e.Level.String()...)
+	buff.B = append(buff.B, " entry: "...)
+	buff.B = append(buff.B, err.Error()...)
+	if r.suppressed > 0 {
+		buff.B = append(buff.B, fmt.Sprintf(" (%d similar errors suppressed)", r.suppressed)...)
+		r.suppressed = 0
+	}
+	buff.B = append(buff.B, newLine)
+	_, _ = r.writer.Write(buff.B)
+	BytePool().Put(buff)
+}
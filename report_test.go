@@ -0,0 +1,137 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestReportError(t *testing.T) {
+	l := New()
+	c := NewConsoleBuilder().WithWriter(failingWriter{}).Build()
+	l.AddHandler(c, AllLevels...)
+
+	var reported []error
+	var handler Handler
+	l.SetErrorFunc(func(h Handler, e Entry, err error) {
+		handler = h
+		reported = append(reported, err)
+	})
+	l.Info("info")
+	if len(reported) != 1 || reported[0].Error() != "disk full" {
+		t.Fatalf("Expected '%s' Got '%v'", "disk full", reported)
+	}
+	if handler != c {
+		t.Errorf("Expected reporting handler to be the console logger Got '%T'", handler)
+	}
+
+	// nil restores the default rather than panicking on the next error
+	l.SetErrorFunc(nil)
+	if l.load().errorFunc == nil {
+		t.Error("Expected the default error function to be restored")
+	}
+}
+
+func TestErrorWriter(t *testing.T) {
+	buff := new(bytes.Buffer)
+	fn := NewErrorWriter(buff, 20*time.Millisecond)
+	h := &testHandler{}
+	e := Entry{Level: ErrorLevel}
+	err := errors.New("closed pipe")
+
+	fn(h, e, err)
+	fn(h, e, err)
+	fn(h, e, err)
+	expected := "log: handler *log.testHandler failed to log ERROR entry: closed pipe\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	buff.Reset()
+	time.Sleep(30 * time.Millisecond)
+	fn(h, e, err)
+	expected = "log: handler *log.testHandler failed to log ERROR entry: closed pipe (2 similar errors suppressed)\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+type panicHandler struct{}
+
+func (panicHandler) Log(Entry) {
+	panic("boom")
+}
+
+func TestHandlerPanicIsolation(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(panicHandler{}, InfoLevel)
+	l.AddHandler(&testHandler{writer: buff}, InfoLevel)
+
+	var reported error
+	l.SetErrorFunc(func(_ Handler, _ Entry, err error) {
+		reported = err
+	})
+	l.Info("info")
+
+	if buff.String() != "INFO info\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO info\n", buff.String())
+	}
+	var pe *HandlerPanicError
+	if !errors.As(reported, &pe) || pe.Value != "boom" || len(pe.Stack) == 0 {
+		t.Fatalf("Expected HandlerPanicError Got '%v'", reported)
+	}
+	if reported.Error() != "handler log.panicHandler panicked: boom" {
+		t.Errorf("Expected '%s' Got '%s'", "handler log.panicHandler panicked: boom", reported.Error())
+	}
+
+	// async handlers must not crash their goroutine either
+	reported = nil
+	a := NewAsyncBuilder().Build(panicHandler{})
+	l.RemoveHandler(panicHandler{})
+	l.AddHandler(a, InfoLevel)
+	l.Info("async")
+	_ = a.Close()
+	if !errors.As(reported, &pe) {
+		t.Errorf("Expected HandlerPanicError Got '%v'", reported)
+	}
+}
+
+func TestSetErrorFuncAsync(t *testing.T) {
+	l := New()
+	a := NewAsyncBuilder().Build(NewConsoleBuilder().WithWriter(failingWriter{}).Build())
+	l.AddHandler(a, InfoLevel)
+
+	var m sync.Mutex
+	var reported int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			l.Info("async")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		l.SetErrorFunc(func(Handler, Entry, error) {
+			m.Lock()
+			reported++
+			m.Unlock()
+		})
+	}
+	<-done
+	_ = a.Close()
+
+	m.Lock()
+	defer m.Unlock()
+	if reported == 0 {
+		t.Error("Expected errors reported from the async handler")
+	}
+}
@@ -0,0 +1,265 @@
+package log
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SamplerBuilder is used to configure and create a new Sampler
+type SamplerBuilder struct {
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+	keyFunc    func(Entry) string
+}
+
+// NewSamplerBuilder creates a new SamplerBuilder that, per level and message, logs the first 100 entries
+// each second and every 100th thereafter.
+func NewSamplerBuilder() *SamplerBuilder {
+	return &SamplerBuilder{
+		interval:   time.Second,
+		first:      100,
+		thereafter: 100,
+		keyFunc:    messageKey,
+	}
+}
+
+// WithInterval sets the interval after which the counts of each key are reset.
+func (b *SamplerBuilder) WithInterval(interval time.Duration) *SamplerBuilder {
+	b.interval = interval
+	return b
+}
+
+// WithFirst sets the number of entries per key logged each interval before sampling begins.
+func (b *SamplerBuilder) WithFirst(n uint64) *SamplerBuilder {
+	b.first = n
+	return b
+}
+
+// WithThereafter sets that every nth entry per key is logged once sampling begins, 0 drops all.
+func (b *SamplerBuilder) WithThereafter(n uint64) *SamplerBuilder {
+	b.thereafter = n
+	return b
+}
+
+// WithKeyFunc sets the function used to group entries, which are always grouped by level as well.
+// The default groups entries by their message.
+func (b *SamplerBuilder) WithKeyFunc(fn func(Entry) string) *SamplerBuilder {
+	b.keyFunc = fn
+	return b
+}
+
+// Build creates a new Sampler.
+func (b *SamplerBuilder) Build() *Sampler {
+	return &Sampler{
+		interval:   b.interval,
+		first:      b.first,
+		thereafter: b.thereafter,
+		keyFunc:    b.keyFunc,
+		counters:   make(map[sampleKey]*sampleCounter),
+		now:        time.Now,
+	}
+}
+
+func messageKey(e Entry) string {
+	return e.Message
+}
+
+type sampleKey struct {
+	level Level
+	key   string
+}
+
+type sampleCounter struct {
+	start      time.Time
+	count      uint64
+	suppressed uint64
+	// instance or handler is where summaries of the key are logged, depending on whether it was sampled
+	// by Middleware or a Handler returned by Wrap.
+	instance *Instance
+	handler  *sampledHandler
+}
+
+// Sampler reduces repetitive entries by logging, per level and key, the first N entries each interval and
+// then every Mth. Once an interval ends a summary entry, at the same level, reports how many entries of
+// the key were suppressed. Summaries are emitted as entries are logged so are delayed until the next
+// entry after the interval ends, or until the Sampler or wrapped handler is flushed.
+//
+// A Sampler can be applied to all handlers, using Use(sampler.Middleware), or a single handler by
+// registering the handler returned by Wrap. When used as middleware call Flush before shutting down so
+// that outstanding summaries are logged, wrapped handlers do so when flushed or closed.
+type Sampler struct {
+	m          sync.Mutex
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+	keyFunc    func(Entry) string
+	counters   map[sampleKey]*sampleCounter
+	lastSweep  time.Time
+	now        func() time.Time
+}
+
+// Middleware samples entries for all handlers, summaries are logged via the entry's Instance.
+func (s *Sampler) Middleware(e Entry) (Entry, bool) {
+	if e.sampleSummary {
+		return e, true
+	}
+	keep, summaries := s.sample(e, e.logger(), nil)
+	for _, summary := range summaries {
+		summary.log()
+	}
+	return e, keep
+}
+
+// Flush logs summaries of the entries suppressed so far in the current intervals, without waiting
+// for the intervals to end.
+func (s *Sampler) Flush() {
+	s.flush(nil)
+}
+
+// Wrap returns a Handler sampling the entries passed to the provided handler only, summaries are
+// logged to that handler. The returned Handler must be used when removing the handler.
+func (s *Sampler) Wrap(h Handler) Handler {
+	return &sampledHandler{sampler: s, handler: h}
+}
+
+type sampledHandler struct {
+	sampler *Sampler
+	handler Handler
+}
+
+// Name returns the name of the wrapped Handler.
+func (h *sampledHandler) Name() string {
+	return "sampled(" + handlerName(h.handler) + ")"
+}
+
+// Log handles the log entry
+func (h *sampledHandler) Log(e Entry) {
+	if e.sampleSummary {
+		h.handler.Log(e)
+		return
+	}
+	keep, summaries := h.sampler.sample(e, nil, h)
+	for _, summary := range summaries {
+		summary.log()
+	}
+	if keep {
+		h.handler.Log(e)
+	}
+}
+
+// sample returns if the entry should be logged along with any summaries of ended intervals, the
+// instance or handler is where summaries of the entry's key are logged.
+func (s *Sampler) sample(e Entry, instance *Instance, handler *sampledHandler) (keep bool, summaries []pendingSummary) {
+	k := sampleKey{level: e.Level, key: s.keyFunc(e)}
+	now := s.now()
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if now.Sub(s.lastSweep) >= s.interval {
+		s.lastSweep = now
+		for ck, c := range s.counters {
+			if ck != k && now.Sub(c.start) >= s.interval {
+				if c.suppressed > 0 {
+					summaries = append(summaries, s.summary(ck, c, now))
+				}
+				delete(s.counters, ck)
+			}
+		}
+	}
+
+	c, found := s.counters[k]
+	if !found {
+		c = &sampleCounter{start: now, instance: instance, handler: handler}
+		s.counters[k] = c
+	} else if now.Sub(c.start) >= s.interval {
+		if c.suppressed > 0 {
+			summaries = append(summaries, s.summary(k, c, now))
+		}
+		*c = sampleCounter{start: now, instance: instance, handler: handler}
+	}
+
+	c.count++
+	if c.count <= s.first || s.thereafter > 0 && (c.count-s.first)%s.thereafter == 0 {
+		return true, summaries
+	}
+	c.suppressed++
+	return false, summaries
+}
+
+// flush logs summaries of the entries suppressed so far for the wrapped handler, or all when nil, and
+// resets their suppressed counts. Wrapped handlers are identified by their wrapper as the handlers
+// themselves may not be comparable.
+func (s *Sampler) flush(handler *sampledHandler) {
+	var summaries []pendingSummary
+	now := s.now()
+
+	s.m.Lock()
+	for k, c := range s.counters {
+		if c.suppressed > 0 && (handler == nil || c.handler == handler) {
+			summaries = append(summaries, s.summary(k, c, now))
+			c.suppressed = 0
+		}
+	}
+	s.m.Unlock()
+
+	for _, summary := range summaries {
+		summary.log()
+	}
+}
+
+// pendingSummary is a summary entry along with the handler it is logged to, if sampled by a Handler
+// returned by Wrap, otherwise it is logged via its Instance.
+type pendingSummary struct {
+	entry   Entry
+	handler Handler
+}
+
+func (p pendingSummary) log() {
+	if p.handler != nil {
+		p.handler.Log(p.entry)
+		return
+	}
+	p.entry.instance.HandleEntry(p.entry)
+}
+
+func (s *Sampler) summary(k sampleKey, c *sampleCounter, now time.Time) pendingSummary {
+	e := Entry{
+		Message:   "suppressed " + strconv.FormatUint(c.suppressed, 10) + " entries",
+		Timestamp: now,
+		Level:     k.level,
+		Fields: []Field{
+			F("sample_key", k.key),
+			F("suppressed", c.suppressed),
+		},
+		instance:      c.instance,
+		sampleSummary: true,
+	}
+	p := pendingSummary{entry: e}
+	if c.handler != nil {
+		p.handler = c.handler.handler
+	}
+	return p
+}
+
+// Flush logs summaries of the entries suppressed so far and flushes the wrapped Handler if it
+// implements Flusher.
+func (h *sampledHandler) Flush() error {
+	h.sampler.flush(h)
+	if f, ok := h.handler.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close logs summaries of the entries suppressed so far and closes the wrapped Handler if it
+// implements Closer.
+func (h *sampledHandler) Close() error {
+	h.sampler.flush(h)
+	if c, ok := h.handler.(Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSampler(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewSamplerBuilder().WithInterval(time.Second).WithFirst(2).WithThereafter(3).Build()
+	s.now = func() time.Time { return now }
+
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(&testHandler{writer: buff}, AllLevels...)
+	l.Use(s.Middleware)
+
+	for i := 0; i < 8; i++ {
+		l.Info("repeated")
+	}
+	l.Warn("repeated")
+	expected := "INFO repeated\nINFO repeated\nINFO repeated\nINFO repeated\nWARN repeated\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	buff.Reset()
+	now = now.Add(time.Second)
+	l.Info("other")
+	expected = "INFO suppressed 4 entries sample_key=repeated suppressed=4\nINFO other\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	// counters reset after the interval
+	buff.Reset()
+	l.Info("repeated")
+	if buff.String() != "INFO repeated\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO repeated\n", buff.String())
+	}
+}
+
+func TestSamplerWrap(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewSamplerBuilder().WithFirst(1).WithThereafter(0).WithKeyFunc(func(e Entry) string {
+		return "all"
+	}).Build()
+	s.now = func() time.Time { return now }
+
+	l := New()
+	sampled := new(bytes.Buffer)
+	all := new(bytes.Buffer)
+	l.AddHandler(s.Wrap(&testHandler{writer: sampled}), InfoLevel)
+	l.AddHandler(&testHandler{writer: all}, InfoLevel)
+
+	l.Info("one")
+	l.Info("two")
+	now = now.Add(time.Second)
+	l.Info("three")
+
+	expected := "INFO one\nINFO suppressed 1 entries sample_key=all suppressed=1\nINFO three\n"
+	if sampled.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, sampled.String())
+	}
+	if all.String() != "INFO one\nINFO two\nINFO three\n" {
+		t.Errorf("Expected '%s' Got '%s'", "INFO one\nINFO two\nINFO three\n", all.String())
+	}
+}
+
+func TestSamplerFlush(t *testing.T) {
+	s := NewSamplerBuilder().WithFirst(1).WithThereafter(0).Build()
+
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(&testHandler{writer: buff}, AllLevels...)
+	l.Use(s.Middleware)
+
+	for i := 0; i < 10; i++ {
+		l.Info("repeated")
+	}
+	s.Flush()
+	expected := "INFO repeated\nINFO suppressed 9 entries sample_key=repeated suppressed=9\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	// already reported summaries are not repeated
+	buff.Reset()
+	s.Flush()
+	if buff.String() != "" {
+		t.Errorf("Expected no summaries Got '%s'", buff.String())
+	}
+}
+
+func TestSamplerWrapShutdown(t *testing.T) {
+	s := NewSamplerBuilder().WithFirst(1).WithThereafter(0).Build()
+
+	l := New()
+	buff := new(bytes.Buffer)
+	l.AddHandler(s.Wrap(&testHandler{writer: buff}), InfoLevel)
+
+	for i := 0; i < 10; i++ {
+		l.Info("repeated")
+	}
+	if err := l.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	expected := "INFO repeated\nINFO suppressed 9 entries sample_key=repeated suppressed=9\n"
+	if buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+}
+
+func TestSamplerWrapNonComparable(t *testing.T) {
+	s := NewSamplerBuilder().WithFirst(1).WithThereafter(0).Build()
+
+	l := New()
+	var entries []string
+	l.AddHandler(s.Wrap(valueHandler{entries: &entries}), InfoLevel)
+
+	for i := 0; i < 10; i++ {
+		l.Info("repeated")
+	}
+	if err := l.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0] != "repeated" || entries[1] != "suppressed 9 entries" {
+		t.Errorf("Expected '[repeated suppressed 9 entries]' Got '%v'", entries)
+	}
+}
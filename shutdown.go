@@ -0,0 +1,87 @@
+package log
+
+import (
+	"context"
+	"time"
+)
+
+// defaultExitFlushTimeout is the default time to wait for handlers to flush before exiting.
+const defaultExitFlushTimeout = 5 * time.Second
+
+// SetExitFlushTimeout sets the maximum time Fatal(), Fatalf(), Panic() and Panicf() will wait for
+// this Instance's handlers to flush before calling the exit function.
+func (l *Instance) SetExitFlushTimeout(d time.Duration) {
+	l.exitFlushTimeout = d
+}
+
+// Flush waits for any asynchronously queued entries to be processed and then calls Flush on every
+// registered handler implementing Flusher. If the context is done before completion its error is
+// returned, otherwise the first error returned by a handler is.
+func (l *Instance) Flush(ctx context.Context) error {
+	return l.withContext(ctx, func() error {
+		var err error
+		if async := l.load().async; async != nil {
+			err = async.Flush()
+		}
+		for _, h := range l.registeredHandlers() {
+			if f, ok := h.h.(Flusher); ok {
+				if e := f.Flush(); e != nil && err == nil {
+					err = e
+				}
+			}
+		}
+		return err
+	})
+}
+
+// Shutdown stops asynchronous dispatch, draining any queued entries, and then calls Flush and Close on
+// every registered handler implementing Flusher and Closer respectively. Handlers remain registered.
+// If the context is done before completion its error is returned, otherwise the first error returned by
+// a handler is.
+func (l *Instance) Shutdown(ctx context.Context) error {
+	return l.withContext(ctx, func() error {
+		l.SetAsync(nil)
+
+		var err error
+		for _, h := range l.registeredHandlers() {
+			if f, ok := h.h.(Flusher); ok {
+				if e := f.Flush(); e != nil && err == nil {
+					err = e
+				}
+			}
+			if c, ok := h.h.(Closer); ok {
+				if e := c.Close(); e != nil && err == nil {
+					err = e
+				}
+			}
+		}
+		return err
+	})
+}
+
+// withContext runs fn, returning early with the contexts error if it is done first.
+func (l *Instance) withContext(ctx context.Context, fn func() error) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- fn()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// registeredHandlers returns each registered handler once, regardless of how many levels it is registered for.
+func (l *Instance) registeredHandlers() []registeredHandler {
+	return l.load().registeredHandlers()
+}
+
+// exit flushes all handlers, waiting at most the exit flush timeout, before calling the exit function.
+func (l *Instance) exit(code int) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.exitFlushTimeout)
+	_ = l.Flush(ctx)
+	cancel()
+	l.exitFunc(code)
+}
@@ -0,0 +1,135 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type lifecycleHandler struct {
+	m       sync.Mutex
+	logged  int
+	flushed int
+	closed  int
+	block   chan struct{}
+}
+
+func (h *lifecycleHandler) Log(Entry) {
+	h.m.Lock()
+	h.logged++
+	h.m.Unlock()
+}
+
+func (h *lifecycleHandler) Flush() error {
+	if h.block != nil {
+		<-h.block
+	}
+	h.m.Lock()
+	h.flushed++
+	h.m.Unlock()
+	return nil
+}
+
+func (h *lifecycleHandler) Close() error {
+	h.m.Lock()
+	h.closed++
+	h.m.Unlock()
+	return errors.New("close error")
+}
+
+func (h *lifecycleHandler) counts() (logged, flushed, closed int) {
+	h.m.Lock()
+	defer h.m.Unlock()
+	return h.logged, h.flushed, h.closed
+}
+
+func TestShutdown(t *testing.T) {
+	l := New()
+	h := &lifecycleHandler{}
+	l.AddHandler(h, AllLevels...)
+	l.SetAsync(NewAsyncBuilder())
+
+	l.Info("info")
+	l.Warn("warn")
+	err := l.Shutdown(context.Background())
+	if err == nil || err.Error() != "close error" {
+		t.Errorf("Expected '%s' Got '%v'", "close error", err)
+	}
+	logged, flushed, closed := h.counts()
+	if logged != 2 {
+		t.Errorf("Expected '%d' logged Got '%d'", 2, logged)
+	}
+	// registered for all levels but must only be flushed and closed once
+	if flushed != 1 || closed != 1 {
+		t.Errorf("Expected flushed and closed once Got '%d' '%d'", flushed, closed)
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	l := New()
+	h := &lifecycleHandler{block: make(chan struct{})}
+	defer close(h.block)
+	l.AddHandler(h, InfoLevel)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected '%v' Got '%v'", context.DeadlineExceeded, err)
+	}
+}
+
+func TestFatalFlushes(t *testing.T) {
+	l := New()
+	h := &lifecycleHandler{}
+	l.AddHandler(h, AllLevels...)
+	l.SetAsync(NewAsyncBuilder())
+
+	var code, logged, flushed int
+	l.SetExitFunc(func(c int) {
+		code = c
+		logged, flushed, _ = h.counts()
+	})
+	l.Fatal("fatal")
+	if code != 1 || logged != 1 || flushed != 1 {
+		t.Errorf("Expected entry logged and flushed before exit Got logged '%d' flushed '%d' code '%d'", logged, flushed, code)
+	}
+
+	code = 0
+	l = New()
+	blocked := &lifecycleHandler{block: make(chan struct{})}
+	defer close(blocked.block)
+	l.AddHandler(blocked, AllLevels...)
+	l.SetExitFunc(func(c int) { code = c })
+	l.SetExitFlushTimeout(10 * time.Millisecond)
+	l.Panic("panic")
+	if code != 1 {
+		t.Errorf("Expected exit after flush timeout Got code '%d'", code)
+	}
+}
+
+func TestShutdownNonComparableHandler(t *testing.T) {
+	l := New()
+	var entries []string
+	l.AddHandler(valueHandler{entries: &entries}, AllLevels...)
+	l.AddHandler(valueHandler{entries: &entries}, InfoLevel)
+	h := &lifecycleHandler{}
+	l.AddHandler(h, AllLevels...)
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Errorf("Unexpected error '%s'", err)
+	}
+	if err := l.Shutdown(context.Background()); err == nil || err.Error() != "close error" {
+		t.Errorf("Expected '%s' Got '%v'", "close error", err)
+	}
+	var code int
+	l.SetExitFunc(func(c int) { code = c })
+	l.Fatal("fatal")
+	if code != 1 || len(entries) != 1 {
+		t.Errorf("Expected entry logged before exit Got '%v' code '%d'", entries, code)
+	}
+	if _, flushed, closed := h.counts(); flushed != 3 || closed != 1 {
+		t.Errorf("Expected flushed three times and closed once Got '%d' '%d'", flushed, closed)
+	}
+}
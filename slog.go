@@ -21,10 +21,7 @@ type slogHandler struct {
 // Enabled returns if the current logging level is enabled. In the case of this log package in this Level has a
 // handler registered.
 func (s *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
-	rw.RLock()
-	_, enabled := logHandlers[convertSlogLevel(level)]
-	rw.RUnlock()
-	return enabled
+	return Default().Enabled(convertSlogLevel(level))
 }
 
 func (s *slogHandler) Handle(ctx context.Context, record slog.Record) error {
@@ -74,7 +71,13 @@ func (s *slogHandler) Handle(ctx context.Context, record slog.Record) error {
 	e.Level = convertSlogLevel(record.Level)
 	e.Timestamp = record.Time
 
-	HandleEntry(e)
+	l := Default()
+	if record.PC != 0 && l.load().callerFor(e.Level) {
+		fs := runtime.CallersFrames([]uintptr{record.PC})
+		f, _ := fs.Next()
+		e.Caller = formatCaller(runtimeext.Frame{Frame: f})
+	}
+	l.HandleEntry(e)
 	return nil
 }
 
@@ -139,37 +142,48 @@ func (s *slogHandler) WithGroup(name string) slog.Handler {
 	}
 }
 
+// convertSlogLevel returns the registered level with the same slog.Level value or, if there is none, the
+// least severe level above it. Values above all registered levels return the most severe level.
 func convertSlogLevel(level slog.Level) Level {
-	switch level {
-	case slog.LevelDebug:
-		return DebugLevel
-	case slog.LevelInfo:
-		return InfoLevel
-	case SlogNoticeLevel:
-		return NoticeLevel
-	case slog.LevelWarn:
-		return WarnLevel
-	case slog.LevelError:
-		return ErrorLevel
-	case SlogPanicLevel:
-		return PanicLevel
-	case SlogAlertLevel:
-		return AlertLevel
-	case SlogFatalLevel:
-		return FatalLevel
-	default:
+	r := loadLevels()
+	var (
+		found   bool
+		nearest Level
+		highest Level
+	)
+	for i, def := range r.defs {
+		if def == nil {
+			continue
+		}
+		lvl := Level(i)
 		switch {
-		case level > slog.LevelInfo && level < slog.LevelWarn:
-			return NoticeLevel
-		case level > slog.LevelError && level <= SlogPanicLevel:
-			return PanicLevel
-		case level > SlogPanicLevel && level <= SlogAlertLevel:
-			return AlertLevel
-		case level > SlogAlertLevel && level <= SlogFatalLevel:
-			return FatalLevel
+		case slog.Level(def.Slog) == level:
+			return lvl
+		case slog.Level(def.Slog) > level && (!found || def.Slog < r.defs[nearest].Slog):
+			found, nearest = true, lvl
+		}
+		if r.defs[highest] == nil || def.Slog > r.defs[highest].Slog {
+			highest = lvl
 		}
-		return InfoLevel
 	}
+	if found {
+		return nearest
+	}
+	return highest
+}
+
+// LevelFromSlog converts the slog.Level to the equivalent registered Level.
+func LevelFromSlog(level slog.Level) Level {
+	return convertSlogLevel(level)
+}
+
+// SlogLevel returns the registered slog.Level equivalent of the level. Unregistered levels are mapped as
+// InfoLevel.
+func (l Level) SlogLevel() slog.Level {
+	if def, ok := l.Definition(); ok {
+		return slog.Level(def.Slog)
+	}
+	return slog.LevelInfo
 }
 
 var (
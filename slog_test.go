@@ -0,0 +1,84 @@
+//go:build go1.21
+// +build go1.21
+
+package log
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSlogRedaction(t *testing.T) {
+	SetDefault(New())
+	h := &fieldsHandler{}
+	AddHandler(h, AllLevels...)
+	SetRedactor(NewRedactorBuilder().WithPaths(RedactMask, "group.password").Build())
+
+	logger := slog.New(&slogHandler{})
+	logger.Info("slog", slog.Group("group", slog.String("password", "hunter2")))
+
+	if len(h.entries) != 1 {
+		t.Fatalf("Expected '%d' entries Got '%d'", 1, len(h.entries))
+	}
+	assertFields(t, "", h.entries[0].Fields, []Field{G("group", F("password", DefaultMask))})
+}
+
+func TestSlogFieldValues(t *testing.T) {
+	SetDefault(New())
+	h := &fieldsHandler{}
+	AddHandler(h, AllLevels...)
+
+	logger := slog.New(&slogHandler{})
+	logger.Info("slog", "user", "joe", "n", 3, "ok", true, "took", time.Second)
+
+	if len(h.entries) != 1 {
+		t.Fatalf("Expected '%d' entries Got '%d'", 1, len(h.entries))
+	}
+	// bridged attributes set Value so handlers predating typed fields keep working
+	expected := []interface{}{"joe", int64(3), true, time.Second}
+	for i, f := range h.entries[0].Fields {
+		if f.Value != expected[i] || f.Kind() != KindAny {
+			t.Errorf("%s: Expected '%v' Got '%v' of kind '%d'", f.Key, expected[i], f.Value, f.Kind())
+		}
+	}
+}
+
+func TestConvertSlogLevel(t *testing.T) {
+	tests := []struct {
+		slog     slog.Level
+		expected Level
+	}{
+		{slog: slog.LevelDebug, expected: DebugLevel},
+		{slog: slog.LevelInfo, expected: InfoLevel},
+		{slog: SlogNoticeLevel, expected: NoticeLevel},
+		// values without a registered level map to the least severe level above them
+		{slog: slog.LevelDebug - 4, expected: DebugLevel},
+		{slog: slog.LevelDebug + 1, expected: InfoLevel},
+		{slog: slog.LevelInfo + 1, expected: NoticeLevel},
+		{slog: slog.LevelInfo + 3, expected: WarnLevel},
+		{slog: slog.LevelWarn + 1, expected: ErrorLevel},
+		{slog: slog.LevelWarn + 3, expected: ErrorLevel},
+		{slog: slog.LevelError + 1, expected: PanicLevel},
+		{slog: SlogFatalLevel + 1, expected: FatalLevel},
+	}
+	for _, tt := range tests {
+		if got := convertSlogLevel(tt.slog); got != tt.expected {
+			t.Errorf("%v: Expected '%s' Got '%s'", tt.slog, tt.expected, got)
+		}
+		if got := tt.expected.SlogLevel(); convertSlogLevel(got) != tt.expected {
+			t.Errorf("%s: Expected round trip Got '%s'", tt.expected, convertSlogLevel(got))
+		}
+	}
+
+	registerExtendedLevels(t)
+	if got := convertSlogLevel(slog.LevelDebug - 4); got != TraceLevel {
+		t.Errorf("Expected '%s' Got '%s'", TraceLevel, got)
+	}
+	if got := convertSlogLevel(slog.LevelInfo + 3); got != AuditLevel {
+		t.Errorf("Expected '%s' Got '%s'", AuditLevel, got)
+	}
+	if got := SecurityLevel.SlogLevel(); got != slog.LevelError+2 {
+		t.Errorf("Expected '%v' Got '%v'", slog.LevelError+2, got)
+	}
+}
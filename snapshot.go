@@ -0,0 +1,252 @@
+package log
+
+import "context"
+
+// snapshot is an immutable view of an Instance's handler configuration. It is atomically swapped
+// on every change so that logging never needs to take a lock; it must never be modified once stored.
+type snapshot struct {
+	handlers map[Level][]Handler
+	enabled  levelSet
+	caller   levelSet
+	stack    levelSet
+	// allGoroutines adds the stacks of all goroutines to Fatal entries recording a stack trace.
+	allGoroutines bool
+	async         *AsyncHandler
+	extractors    []func(context.Context) []Field
+	middleware    []Middleware
+	// handlerMiddleware is the middleware chain of individual handlers.
+	handlerMiddleware []handlerChain
+	// chains is the middleware chain of the handler at the same index in handlers, nil when no handler
+	// has a chain, so that dispatching never needs to look handlers up.
+	chains     map[Level][][]Middleware
+	redactor   *Redactor
+	piiScanner *PIIScanner
+	// minLevels is the minimum level of handlers registered using AddHandlerAtLeast.
+	minLevels []*LevelVar
+	// packages filters entries by the package logging them, nil when no rules are set.
+	packages *packageRules
+	// errorFunc is called when a handler reports an error or panics.
+	errorFunc func(Handler, Entry, error)
+}
+
+// clone returns a deep copy of the snapshot which can safely be modified before being stored.
+func (s *snapshot) clone() *snapshot {
+	c := &snapshot{
+		handlers:          make(map[Level][]Handler, len(s.handlers)),
+		caller:            s.caller,
+		stack:             s.stack,
+		allGoroutines:     s.allGoroutines,
+		async:             s.async,
+		extractors:        s.extractors,
+		middleware:        s.middleware,
+		handlerMiddleware: append([]handlerChain(nil), s.handlerMiddleware...),
+		redactor:          s.redactor,
+		piiScanner:        s.piiScanner,
+		minLevels:         append([]*LevelVar(nil), s.minLevels...),
+		packages:          s.packages,
+		errorFunc:         s.errorFunc,
+	}
+	for lvl, handlers := range s.handlers {
+		c.handlers[lvl] = append(make([]Handler, 0, len(handlers)+1), handlers...)
+	}
+	return c
+}
+
+// enabledFor returns if at least one handler is registered for the provided level.
+func (s *snapshot) enabledFor(level Level) bool {
+	return s.enabled.has(level)
+}
+
+// callerFor returns if the calling frame should be recorded for the provided level.
+func (s *snapshot) callerFor(level Level) bool {
+	return s.caller.has(level)
+}
+
+// stackFor returns if a stack trace should be recorded for the provided level.
+func (s *snapshot) stackFor(level Level) bool {
+	return s.stack.has(level)
+}
+
+// updateEnabled recalculates the enabled level bitset.
+func (s *snapshot) updateEnabled() {
+	s.enabled = levelSet{}
+	for lvl, handlers := range s.handlers {
+		if len(handlers) > 0 {
+			s.enabled.add(lvl)
+		}
+	}
+}
+
+// updateChains recalculates the middleware chain of each registered handler.
+func (s *snapshot) updateChains() {
+	s.chains = nil
+	if len(s.handlerMiddleware) == 0 {
+		return
+	}
+	s.chains = make(map[Level][][]Middleware, len(s.handlers))
+	for lvl, handlers := range s.handlers {
+		chains := make([][]Middleware, len(handlers))
+		for i, h := range handlers {
+			chains[i] = s.middlewareFor(h)
+		}
+		s.chains[lvl] = chains
+	}
+}
+
+// minLevelFor returns the LevelVar of the handler when registered using AddHandlerAtLeast.
+func (s *snapshot) minLevelFor(h Handler) *LevelVar {
+	for _, v := range s.minLevels {
+		if sameHandler(v.h, h) {
+			return v
+		}
+	}
+	return nil
+}
+
+// removeMinLevel removes the LevelVar of the handler so that it no longer applies.
+func (s *snapshot) removeMinLevel(h Handler) {
+	for i, v := range s.minLevels {
+		if sameHandler(v.h, h) {
+			s.minLevels = append(s.minLevels[:i:i], s.minLevels[i+1:]...)
+			return
+		}
+	}
+}
+
+// middlewareFor returns the middleware chain added for the handler using UseFor.
+func (s *snapshot) middlewareFor(h Handler) []Middleware {
+	for _, c := range s.handlerMiddleware {
+		if sameHandler(c.h, h) {
+			return c.chain
+		}
+	}
+	return nil
+}
+
+// sameHandler reports whether a and b are the same handler. Handlers are not required to be comparable,
+// a handler whose dynamic type is not, such as a struct value holding a slice, is never the same as any
+// other rather than panicking.
+func sameHandler(a, b Handler) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+func (s *snapshot) removeHandler(h Handler) {
+	for i, c := range s.handlerMiddleware {
+		if sameHandler(c.h, h) {
+			s.handlerMiddleware = append(s.handlerMiddleware[:i:i], s.handlerMiddleware[i+1:]...)
+			break
+		}
+	}
+	s.removeMinLevel(h)
+OUTER:
+	for lvl, handlers := range s.handlers {
+		for i, handler := range handlers {
+			if sameHandler(h, handler) {
+				n := append(handlers[:i], handlers[i+1:]...)
+				if len(n) == 0 {
+					delete(s.handlers, lvl)
+					continue OUTER
+				}
+				s.handlers[lvl] = n
+				continue OUTER
+			}
+		}
+	}
+}
+
+func (s *snapshot) removeHandlerLevels(h Handler, levels ...Level) {
+OUTER:
+	for _, lvl := range levels {
+		handlers := s.handlers[lvl]
+		for i, handler := range handlers {
+			if sameHandler(h, handler) {
+				n := append(handlers[:i], handlers[i+1:]...)
+				if len(n) == 0 {
+					delete(s.handlers, lvl)
+					continue OUTER
+				}
+				s.handlers[lvl] = n
+				continue OUTER
+			}
+		}
+	}
+}
+
+// setHandlerLevels registers the handler for exactly the provided levels, keeping its position for
+// levels it is already registered for.
+func (s *snapshot) setHandlerLevels(h Handler, levels ...Level) {
+	var wanted levelSet
+	for _, lvl := range levels {
+		wanted.add(lvl)
+	}
+	for lvl, handlers := range s.handlers {
+		if !wanted.has(lvl) {
+			s.removeHandlerLevels(h, lvl)
+			continue
+		}
+		for _, handler := range handlers {
+			if sameHandler(handler, h) {
+				wanted.remove(lvl)
+				break
+			}
+		}
+	}
+	for _, lvl := range levels {
+		if wanted.has(lvl) {
+			wanted.remove(lvl)
+			s.handlers[lvl] = append(s.handlers[lvl], h)
+		}
+	}
+}
+
+// handlerLevels returns the levels the handler is registered for, ordered by severity.
+func (s *snapshot) handlerLevels(h Handler) []Level {
+	var levels []Level
+	for lvl, handlers := range s.handlers {
+		for _, handler := range handlers {
+			if sameHandler(handler, h) {
+				levels = append(levels, lvl)
+				break
+			}
+		}
+	}
+	sortLevels(levels)
+	return levels
+}
+
+// registeredHandler is a registered handler along with the levels it is registered for.
+type registeredHandler struct {
+	h      Handler
+	levels []Level
+}
+
+// registeredHandlers returns each registered handler once, ordered by the least severe level they are
+// registered for and then registration order. Handlers whose dynamic type is not comparable cannot be
+// identified and so are returned once for each level they are registered for.
+func (s *snapshot) registeredHandlers() []registeredHandler {
+	levels := make([]Level, 0, len(s.handlers))
+	for lvl := range s.handlers {
+		levels = append(levels, lvl)
+	}
+	sortLevels(levels)
+
+	handlers := make([]registeredHandler, 0, len(s.handlers))
+	for _, lvl := range levels {
+	OUTER:
+		for _, h := range s.handlers[lvl] {
+			for i := range handlers {
+				if sameHandler(handlers[i].h, h) {
+					handlers[i].levels = append(handlers[i].levels, lvl)
+					continue OUTER
+				}
+			}
+			handlers = append(handlers, registeredHandler{h: h, levels: []Level{lvl}})
+		}
+	}
+	return handlers
+}
@@ -0,0 +1,76 @@
+package log
+
+import (
+	"runtime"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+const (
+	maxStackDepth       = 64
+	maxGoroutinesLength = 8 << 20
+)
+
+// SetStackTraceLevels sets the levels for which the Default Instance records a stack trace.
+// see Instance.SetStackTraceLevels for details.
+func SetStackTraceLevels(levels ...Level) {
+	Default().SetStackTraceLevels(levels...)
+}
+
+// SetFatalAllGoroutines sets if the Default Instance includes the stacks of all goroutines in Fatal entries.
+// see Instance.SetFatalAllGoroutines for details.
+func SetFatalAllGoroutines(enabled bool) {
+	Default().SetFatalAllGoroutines(enabled)
+}
+
+// SetStackTraceLevels sets the levels for which the calling goroutine's stack trace is added to every
+// entry as a "stack" field, replacing any previously set. It is intended for PanicLevel, AlertLevel and
+// FatalLevel. Call with no levels to disable.
+//
+// The stack is a []string of frames, in the same package/file:line:function form as WithError, starting
+// at the caller with the frames of this package removed.
+func (l *Instance) SetStackTraceLevels(levels ...Level) {
+	l.update(func(s *snapshot) {
+		s.stack = levelSet{}
+		for _, lvl := range levels {
+			s.stack.add(lvl)
+		}
+	})
+}
+
+// SetFatalAllGoroutines sets if the stacks of all goroutines, as formatted by runtime.Stack, are added as a
+// "goroutines" field to Fatal entries. It only applies when FatalLevel is set using SetStackTraceLevels.
+func (l *Instance) SetFatalAllGoroutines(enabled bool) {
+	l.update(func(s *snapshot) {
+		s.allGoroutines = enabled
+	})
+}
+
+// stackTrace returns the formatted frames of the current goroutine, skip being the number of frames
+// to skip with 0 identifying the frame for runtime.Callers itself.
+func stackTrace(skip int) []string {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	stack := make([]string, 0, n)
+	for {
+		f, more := frames.Next()
+		stack = append(stack, formatCaller(runtimeext.Frame{Frame: f}))
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// allGoroutines returns the stacks of all goroutines as formatted by runtime.Stack.
+func allGoroutines() string {
+	b := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(b, true)
+		if n < len(b) || len(b) >= maxGoroutinesLength {
+			return string(b[:n])
+		}
+		b = make([]byte, len(b)*2)
+	}
+}
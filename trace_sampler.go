@@ -0,0 +1,128 @@
+package log
+
+import (
+	"fmt"
+	"math"
+)
+
+// TraceSamplerBuilder is used to configure and create a new TraceSampler
+type TraceSamplerBuilder struct {
+	keys        []string
+	rates       map[Level]float64
+	keepUnkeyed bool
+}
+
+// NewTraceSamplerBuilder creates a new TraceSamplerBuilder keyed by the "request_id" or "trace_id" field
+// that keeps every entry until rates are configured.
+func NewTraceSamplerBuilder() *TraceSamplerBuilder {
+	return &TraceSamplerBuilder{
+		keys:        []string{"request_id", "trace_id"},
+		rates:       make(map[Level]float64),
+		keepUnkeyed: true,
+	}
+}
+
+// WithKeys sets the field keys, checked in order, whose value the sampling decision is based on.
+func (b *TraceSamplerBuilder) WithKeys(keys ...string) *TraceSamplerBuilder {
+	b.keys = append([]string(nil), keys...)
+	return b
+}
+
+// WithRate sets the fraction, between 0 and 1, of keys whose entries are kept for the provided levels.
+// Levels without a rate are always kept.
+func (b *TraceSamplerBuilder) WithRate(rate float64, levels ...Level) *TraceSamplerBuilder {
+	for _, level := range levels {
+		b.rates[level] = rate
+	}
+	return b
+}
+
+// WithKeepUnkeyed sets whether entries without any of the key fields are kept, the default is true.
+func (b *TraceSamplerBuilder) WithKeepUnkeyed(keep bool) *TraceSamplerBuilder {
+	b.keepUnkeyed = keep
+	return b
+}
+
+// Build creates a new TraceSampler.
+func (b *TraceSamplerBuilder) Build() *TraceSampler {
+	s := &TraceSampler{
+		keys:        append([]string(nil), b.keys...),
+		thresholds:  make(map[Level]uint64, len(b.rates)),
+		keepUnkeyed: b.keepUnkeyed,
+	}
+	for level, rate := range b.rates {
+		s.thresholds[level] = rateThreshold(rate)
+	}
+	return s
+}
+
+// rateThreshold returns the hash value below which a key is sampled for the rate.
+func rateThreshold(rate float64) uint64 {
+	switch {
+	case rate <= 0:
+		return 0
+	case rate >= 1:
+		return math.MaxUint64
+	default:
+		return uint64(rate * math.MaxUint64)
+	}
+}
+
+// TraceSampler samples entries based on a hash of a key field, such as a request or trace ID, so that
+// all entries sharing a key are either kept or dropped together at a level. A key kept at a lower rate
+// is also kept at every higher rate, and the decision is the same across processes using the same
+// keys and rates.
+//
+// A TraceSampler can be applied to all handlers, using Use(sampler.Middleware), or a single handler
+// using UseFor.
+type TraceSampler struct {
+	keys        []string
+	thresholds  map[Level]uint64
+	keepUnkeyed bool
+}
+
+// Middleware drops the entry if its key is not sampled at the entry's level.
+func (s *TraceSampler) Middleware(e Entry) (Entry, bool) {
+	return e, s.Sampled(e)
+}
+
+// Sampled returns if the entry should be kept.
+func (s *TraceSampler) Sampled(e Entry) bool {
+	threshold, found := s.thresholds[e.Level]
+	if !found {
+		return true
+	}
+	for _, key := range s.keys {
+		for _, f := range e.Fields {
+			if f.Key != key {
+				continue
+			}
+			if threshold == math.MaxUint64 {
+				return true
+			}
+			var h uint64
+			if str, ok := f.stringValue(); ok {
+				h = hashKey(str)
+			} else {
+				h = hashKey(fmt.Sprint(f.Interface()))
+			}
+			return h < threshold
+		}
+	}
+	return s.keepUnkeyed
+}
+
+// hashKey returns the FNV-1a hash of s mixed so that its value is uniformly distributed.
+func hashKey(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
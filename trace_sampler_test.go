@@ -0,0 +1,73 @@
+package log
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestTraceSampler(t *testing.T) {
+	s := NewTraceSamplerBuilder().
+		WithRate(0.25, DebugLevel).
+		WithRate(0.5, InfoLevel).
+		WithRate(0, NoticeLevel).
+		Build()
+
+	var debug, info int
+	for i := 0; i < 10000; i++ {
+		e := Entry{Fields: []Field{F("request_id", strconv.Itoa(i))}}
+
+		e.Level = DebugLevel
+		debugKept := s.Sampled(e)
+		e.Level = InfoLevel
+		infoKept := s.Sampled(e)
+
+		if debugKept {
+			debug++
+			if !infoKept {
+				t.Fatalf("Expected request %d sampled at debug to also be sampled at info", i)
+			}
+		}
+		if infoKept {
+			info++
+		}
+		if infoKept != s.Sampled(e) {
+			t.Fatalf("Expected consistent decision for request %d", i)
+		}
+
+		e.Level = NoticeLevel
+		if s.Sampled(e) {
+			t.Fatalf("Expected notice entries to be dropped")
+		}
+		e.Level = ErrorLevel
+		if !s.Sampled(e) {
+			t.Fatalf("Expected error entries without a rate to be kept")
+		}
+	}
+	if debug < 2250 || debug > 2750 {
+		t.Errorf("Expected about 2500 debug entries kept Got %d", debug)
+	}
+	if info < 4750 || info > 5250 {
+		t.Errorf("Expected about 5000 info entries kept Got %d", info)
+	}
+}
+
+func TestTraceSamplerKeys(t *testing.T) {
+	s := NewTraceSamplerBuilder().WithKeys("trace_id").WithRate(0, InfoLevel).Build()
+	if !s.Sampled(Entry{Level: InfoLevel, Fields: []Field{F("request_id", "1")}}) {
+		t.Errorf("Expected unkeyed entry to be kept")
+	}
+	if s.Sampled(Entry{Level: InfoLevel, Fields: []Field{F("trace_id", "1")}}) {
+		t.Errorf("Expected keyed entry to be dropped")
+	}
+
+	s = NewTraceSamplerBuilder().WithRate(0.5, InfoLevel).WithKeepUnkeyed(false).Build()
+	if s.Sampled(Entry{Level: InfoLevel}) {
+		t.Errorf("Expected unkeyed entry to be dropped")
+	}
+
+	// non string values are hashed by their formatted value
+	e := Entry{Level: InfoLevel, Fields: []Field{F("request_id", 42)}}
+	if s.Sampled(e) != s.Sampled(Entry{Level: InfoLevel, Fields: []Field{F("request_id", "42")}}) {
+		t.Errorf("Expected int and string keys to match")
+	}
+}
@@ -0,0 +1,188 @@
+// Package tracecontext implements parsing, generation and context propagation of W3C Trace Context
+// traceparent values, see https://www.w3.org/TR/trace-context/.
+//
+// Calling Register with a log Instance adds a context extractor so that entries obtained through
+// GetContext or Ctx automatically include trace_id, span_id and trace_flags fields when the context
+// carries a TraceParent.
+package tracecontext
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	log "github.com/go-playground/log/v8"
+)
+
+const (
+	// Header is the name of the HTTP header carrying the traceparent value.
+	Header = "traceparent"
+
+	// FlagSampled is the trace flag indicating the caller may have recorded the trace.
+	FlagSampled byte = 0x01
+
+	// field keys added to log entries.
+	traceIDKey    = "trace_id"
+	spanIDKey     = "span_id"
+	traceFlagsKey = "trace_flags"
+
+	version00Length = 55
+	invalidVersion  = 0xff
+)
+
+// ErrInvalid is returned when parsing a malformed traceparent value.
+var ErrInvalid = errors.New("tracecontext: invalid traceparent")
+
+// TraceParent is a parsed traceparent value.
+type TraceParent struct {
+	Version byte
+	TraceID [16]byte
+	SpanID  [8]byte
+	Flags   byte
+}
+
+// New generates a new sampled TraceParent with a random trace and span ID.
+func New() TraceParent {
+	tp := TraceParent{Flags: FlagSampled}
+	randomNonZero(tp.TraceID[:])
+	randomNonZero(tp.SpanID[:])
+	return tp
+}
+
+// NewSpan returns a copy of the TraceParent, belonging to the same trace, with a new random span ID.
+func (tp TraceParent) NewSpan() TraceParent {
+	randomNonZero(tp.SpanID[:])
+	return tp
+}
+
+// Sampled returns if the sampled flag is set.
+func (tp TraceParent) Sampled() bool {
+	return tp.Flags&FlagSampled != 0
+}
+
+// IsValid returns if neither the trace nor span ID are all zeros.
+func (tp TraceParent) IsValid() bool {
+	return !isZero(tp.TraceID[:]) && !isZero(tp.SpanID[:])
+}
+
+// TraceIDString returns the lowercase hex encoded trace ID.
+func (tp TraceParent) TraceIDString() string {
+	return hex.EncodeToString(tp.TraceID[:])
+}
+
+// SpanIDString returns the lowercase hex encoded span ID.
+func (tp TraceParent) SpanIDString() string {
+	return hex.EncodeToString(tp.SpanID[:])
+}
+
+// FlagsString returns the lowercase hex encoded trace flags.
+func (tp TraceParent) FlagsString() string {
+	return hex.EncodeToString([]byte{tp.Flags})
+}
+
+// String returns the traceparent header value.
+func (tp TraceParent) String() string {
+	b := make([]byte, version00Length)
+	hex.Encode(b[0:2], []byte{tp.Version})
+	b[2] = '-'
+	hex.Encode(b[3:35], tp.TraceID[:])
+	b[35] = '-'
+	hex.Encode(b[36:52], tp.SpanID[:])
+	b[52] = '-'
+	hex.Encode(b[53:55], []byte{tp.Flags})
+	return string(b)
+}
+
+// Parse parses a traceparent header value. Values of a future version are accepted as long as the
+// fields known to version 00 are valid, in which case any additional fields are ignored.
+func Parse(s string) (tp TraceParent, err error) {
+	if len(s) < version00Length || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return tp, ErrInvalid
+	}
+	var version [1]byte
+	if !decodeLowerHex(version[:], s[0:2]) || version[0] == invalidVersion {
+		return tp, ErrInvalid
+	}
+	tp.Version = version[0]
+	if tp.Version == 0 && len(s) != version00Length {
+		return tp, ErrInvalid
+	}
+	if len(s) > version00Length && s[version00Length] != '-' {
+		return tp, ErrInvalid
+	}
+
+	var flags [1]byte
+	if !decodeLowerHex(tp.TraceID[:], s[3:35]) ||
+		!decodeLowerHex(tp.SpanID[:], s[36:52]) ||
+		!decodeLowerHex(flags[:], s[53:55]) {
+		return tp, ErrInvalid
+	}
+	tp.Flags = flags[0]
+	if !tp.IsValid() {
+		return tp, ErrInvalid
+	}
+	return tp, nil
+}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of the context carrying the TraceParent.
+func NewContext(ctx context.Context, tp TraceParent) context.Context {
+	return context.WithValue(ctx, ctxKey{}, tp)
+}
+
+// FromContext returns the TraceParent carried by the context, if any.
+func FromContext(ctx context.Context) (TraceParent, bool) {
+	tp, ok := ctx.Value(ctxKey{}).(TraceParent)
+	return tp, ok
+}
+
+// Register adds Extract as a context extractor of the log Instance. As extractors belong to an Instance,
+// call it again for the new Instance after replacing the Default using log.SetDefault.
+func Register(l *log.Instance) {
+	l.AddContextExtractor(Extract)
+}
+
+// Extract is a log context extractor returning the trace_id, span_id and trace_flags fields of the
+// TraceParent carried by the context, if any.
+func Extract(ctx context.Context) []log.Field {
+	tp, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return []log.Field{
+		log.F(traceIDKey, tp.TraceIDString()),
+		log.F(spanIDKey, tp.SpanIDString()),
+		log.F(traceFlagsKey, tp.FlagsString()),
+	}
+}
+
+func decodeLowerHex(dst []byte, s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
+
+func randomNonZero(b []byte) {
+	for {
+		_, _ = rand.Read(b)
+		if !isZero(b) {
+			return
+		}
+	}
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
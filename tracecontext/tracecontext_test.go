@@ -0,0 +1,101 @@
+package tracecontext
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	log "github.com/go-playground/log/v8"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", valid: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", valid: true},
+		{value: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what-the-future-will-be-like", valid: true},
+		{value: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.what", valid: false},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", valid: false},
+		{value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", valid: false},
+		{value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", valid: false},
+		{value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", valid: false},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", valid: false},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", valid: false},
+		{value: "00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", valid: false},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01", valid: false},
+	}
+
+	for i, tt := range tests {
+		tp, err := Parse(tt.value)
+		if (err == nil) != tt.valid {
+			t.Errorf("Test %d: Expected valid '%t' Got '%v'", i, tt.valid, err)
+			continue
+		}
+		if tt.valid && tp.Version == 0 && tp.String() != tt.value {
+			t.Errorf("Test %d: Expected '%s' Got '%s'", i, tt.value, tp.String())
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tp := New()
+	if !tp.IsValid() || !tp.Sampled() {
+		t.Fatalf("Expected valid sampled TraceParent Got '%s'", tp)
+	}
+	parsed, err := Parse(tp.String())
+	if err != nil || parsed != tp {
+		t.Errorf("Expected '%s' Got '%s' '%v'", tp, parsed, err)
+	}
+	child := tp.NewSpan()
+	if child.TraceID != tp.TraceID || child.SpanID == tp.SpanID {
+		t.Errorf("Expected same trace with new span Got '%s' from '%s'", child, tp)
+	}
+}
+
+type testHandler struct {
+	buff bytes.Buffer
+}
+
+func (h *testHandler) Log(e log.Entry) {
+	h.buff.WriteString(e.Message)
+	for _, f := range e.Fields {
+		h.buff.WriteString(" " + f.Key + "=" + f.Value.(string))
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	defer log.SetDefault(log.Default())
+	log.SetDefault(log.New())
+	Register(log.Default())
+	h := new(testHandler)
+	log.AddHandler(h, log.InfoLevel)
+
+	tp, _ := Parse("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := NewContext(context.Background(), tp)
+	log.Ctx(ctx).Info("traced")
+	expected := "traced trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 trace_flags=01"
+	if h.buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, h.buff.String())
+	}
+}
+
+func TestRegisterInstance(t *testing.T) {
+	l := log.New()
+	h := new(testHandler)
+	l.AddHandler(h, log.InfoLevel)
+
+	ctx := NewContext(context.Background(), New())
+	l.GetContext(ctx).Info("untraced")
+	if h.buff.String() != "untraced" {
+		t.Errorf("Expected '%s' Got '%s'", "untraced", h.buff.String())
+	}
+
+	h.buff.Reset()
+	Register(l)
+	l.GetContext(ctx).Info("traced")
+	if !bytes.Contains(h.buff.Bytes(), []byte(" trace_id=")) {
+		t.Errorf("Expected trace fields Got '%s'", h.buff.String())
+	}
+}
@@ -0,0 +1,266 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// VerbosityBuilder is used to configure and create a new Verbosity
+type VerbosityBuilder struct {
+	l            *Instance
+	restoreAfter time.Duration
+	debugToggle  bool
+}
+
+// NewVerbosityBuilder creates a new VerbosityBuilder for the Default Instance that steps handler levels
+// and restores them 15 minutes after the last change.
+func NewVerbosityBuilder() *VerbosityBuilder {
+	return &VerbosityBuilder{
+		restoreAfter: 15 * time.Minute,
+	}
+}
+
+// WithInstance sets the Instance whose handler levels are changed instead of the Default Instance.
+func (b *VerbosityBuilder) WithInstance(l *Instance) *VerbosityBuilder {
+	b.l = l
+	return b
+}
+
+// WithRestoreAfter sets how long after the last change the previous levels are restored, 0 disables
+// restoring automatically.
+func (b *VerbosityBuilder) WithRestoreAfter(d time.Duration) *VerbosityBuilder {
+	b.restoreAfter = d
+	return b
+}
+
+// WithDebugToggle makes SIGUSR1 toggle Debug on all handlers, instead of stepping their minimum level
+// down, and SIGUSR2 restore the previous levels.
+func (b *VerbosityBuilder) WithDebugToggle(toggle bool) *VerbosityBuilder {
+	b.debugToggle = toggle
+	return b
+}
+
+// Build creates a new Verbosity.
+func (b *VerbosityBuilder) Build() *Verbosity {
+	return &Verbosity{
+		l:            b.l,
+		restoreAfter: b.restoreAfter,
+		debugToggle:  b.debugToggle,
+	}
+}
+
+// Verbosity temporarily changes the levels of all registered handlers, either by stepping the minimum
+// level of every handler down or up through the registered levels or by enabling Debug, logging a
+// Notice entry describing each change. The levels from before the first change are restored by
+// Restore, Stop or automatically after the configured duration.
+//
+// Calling Notify changes verbosity when the process receives SIGUSR1 or SIGUSR2, for daemons without
+// another way to change log configuration at runtime.
+type Verbosity struct {
+	l            *Instance
+	restoreAfter time.Duration
+	debugToggle  bool
+
+	m     sync.Mutex
+	saved []savedLevels
+	step  int
+	debug bool
+	timer *time.Timer
+	stop  chan struct{}
+}
+
+func (v *Verbosity) logger() *Instance {
+	if v.l == nil {
+		return Default()
+	}
+	return v.l
+}
+
+// StepDown lowers the minimum level of every handler by one registered level, making logging more
+// verbose.
+func (v *Verbosity) StepDown() {
+	v.m.Lock()
+	defer v.m.Unlock()
+	v.shift(-1)
+}
+
+// StepUp raises the minimum level of every handler by one registered level, making logging less
+// verbose.
+func (v *Verbosity) StepUp() {
+	v.m.Lock()
+	defer v.m.Unlock()
+	v.shift(1)
+}
+
+// ToggleDebug enables Debug on every handler, or restores the previous levels when already enabled.
+func (v *Verbosity) ToggleDebug() {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	if v.debug {
+		v.restore()
+		return
+	}
+	v.save()
+	v.debug = true
+	v.step = 0
+	for _, saved := range v.saved {
+		v.setMin(saved, DebugLevel)
+	}
+	v.resetTimer()
+	v.logger().WithFields(v.changeFields(DebugLevel)...).Notice("debug logging enabled")
+}
+
+// Restore restores the levels from before the first change.
+func (v *Verbosity) Restore() {
+	v.m.Lock()
+	defer v.m.Unlock()
+	v.restore()
+}
+
+// Stop stops handling signals started by Notify and restores the levels from before the first change.
+func (v *Verbosity) Stop() {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	if v.stop != nil {
+		close(v.stop)
+		v.stop = nil
+	}
+	v.restore()
+}
+
+func (v *Verbosity) shift(n int) {
+	v.save()
+	all := AllLevels
+	v.step += n
+	if v.step < 1-len(all) {
+		v.step = 1 - len(all)
+	} else if v.step > len(all)-1 {
+		v.step = len(all) - 1
+	}
+	if v.step == 0 {
+		v.restore()
+		return
+	}
+	v.debug = false
+
+	min := FatalLevel
+	for _, saved := range v.saved {
+		if level := shiftLevel(all, saved.min, v.step); level.Severity() < min.Severity() {
+			min = level
+		}
+	}
+	e := v.logger().WithFields(append(v.changeFields(min), F("step", v.step))...)
+	if n > 0 {
+		// logged before applying so it is emitted by the more verbose configuration
+		e.Notice("log verbosity decreased")
+	}
+	for _, saved := range v.saved {
+		v.setMin(saved, shiftLevel(all, saved.min, v.step))
+	}
+	v.resetTimer()
+	if n < 0 {
+		e.Notice("log verbosity increased")
+	}
+}
+
+// savedLevels are the levels of a handler before the first change.
+type savedLevels struct {
+	h      Handler
+	levels []Level
+	min    Level
+	minVar *LevelVar
+}
+
+// save records the current handler levels when not already changed.
+func (v *Verbosity) save() {
+	if v.saved != nil {
+		return
+	}
+	v.saved = []savedLevels{}
+	for _, info := range v.logger().Handlers() {
+		saved := savedLevels{h: info.Handler, levels: info.Levels, minVar: info.MinLevel, min: FatalLevel}
+		if info.MinLevel != nil {
+			saved.min = info.MinLevel.Level()
+		} else if len(info.Levels) > 0 {
+			saved.min = info.Levels[0]
+		}
+		v.saved = append(v.saved, saved)
+	}
+}
+
+func (v *Verbosity) restore() {
+	if v.timer != nil {
+		v.timer.Stop()
+		v.timer = nil
+	}
+	if v.saved == nil {
+		return
+	}
+	l := v.logger()
+	for _, saved := range v.saved {
+		if saved.minVar != nil {
+			saved.minVar.Set(saved.min)
+			continue
+		}
+		// a handler removed in the meantime has no levels and must not be registered again
+		if len(l.HandlerLevels(saved.h)) > 0 {
+			l.SetHandlerLevels(saved.h, saved.levels...)
+		}
+	}
+	v.saved = nil
+	v.step = 0
+	v.debug = false
+	l.Notice("log verbosity restored")
+}
+
+func (v *Verbosity) setMin(saved savedLevels, level Level) {
+	if saved.minVar != nil {
+		saved.minVar.Set(level)
+		return
+	}
+	v.logger().SetHandlerLevels(saved.h, LevelsAtLeast(level)...)
+}
+
+func (v *Verbosity) resetTimer() {
+	if v.timer != nil {
+		v.timer.Stop()
+		v.timer = nil
+	}
+	if v.restoreAfter <= 0 {
+		return
+	}
+	var t *time.Timer
+	t = time.AfterFunc(v.restoreAfter, func() {
+		v.m.Lock()
+		defer v.m.Unlock()
+		if v.timer == t {
+			v.restore()
+		}
+	})
+	v.timer = t
+}
+
+func (v *Verbosity) changeFields(min Level) []Field {
+	fields := []Field{F("min_level", min.String())}
+	if v.restoreAfter > 0 {
+		fields = append(fields, F("restore_after", v.restoreAfter))
+	}
+	return fields
+}
+
+// shiftLevel returns the level n positions away from level in the severity ordered levels.
+func shiftLevel(levels []Level, level Level, n int) Level {
+	i := 0
+	for i < len(levels) && levels[i].Severity() < level.Severity() {
+		i++
+	}
+	i += n
+	if i < 0 {
+		i = 0
+	} else if i >= len(levels) {
+		i = len(levels) - 1
+	}
+	return levels[i]
+}
@@ -0,0 +1,12 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package log
+
+import "errors"
+
+// Notify is not supported on this platform as it has no SIGUSR1 or SIGUSR2, verbosity can still be
+// changed by calling StepDown, StepUp and ToggleDebug directly.
+func (v *Verbosity) Notify() error {
+	return errors.New("log: verbosity signals are not supported on this platform")
+}
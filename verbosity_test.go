@@ -0,0 +1,104 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerbosityStep(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	th := &testHandler{writer: buff}
+	l.AddHandler(th, InfoLevel, NoticeLevel, ErrorLevel)
+	atLeast := &testHandler{writer: new(bytes.Buffer)}
+	lv := l.AddHandlerAtLeast(atLeast, WarnLevel)
+	v := NewVerbosityBuilder().WithInstance(l).WithRestoreAfter(0).Build()
+
+	v.StepDown()
+	assertLevels(t, l.HandlerLevels(th), AllLevels)
+	if lv.Level() != NoticeLevel {
+		t.Errorf("Expected '%s' Got '%s'", NoticeLevel, lv)
+	}
+	if expected := "NOTICE log verbosity increased min_level=DEBUG step=-1\n"; buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	// stepping below the least severe level keeps it
+	v.StepDown()
+	assertLevels(t, l.HandlerLevels(th), AllLevels)
+	if lv.Level() != InfoLevel {
+		t.Errorf("Expected '%s' Got '%s'", InfoLevel, lv)
+	}
+
+	buff.Reset()
+	v.StepUp()
+	v.StepUp()
+	if expected := "NOTICE log verbosity decreased min_level=DEBUG step=-1\nNOTICE log verbosity restored\n"; buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+	assertLevels(t, l.HandlerLevels(th), []Level{InfoLevel, NoticeLevel, ErrorLevel})
+	if lv.Level() != WarnLevel {
+		t.Errorf("Expected '%s' Got '%s'", WarnLevel, lv)
+	}
+
+	v.StepUp()
+	assertLevels(t, l.HandlerLevels(th), LevelsAtLeast(NoticeLevel))
+	if lv.Level() != ErrorLevel {
+		t.Errorf("Expected '%s' Got '%s'", ErrorLevel, lv)
+	}
+
+	v.Restore()
+	assertLevels(t, l.HandlerLevels(th), []Level{InfoLevel, NoticeLevel, ErrorLevel})
+	if lv.Level() != WarnLevel {
+		t.Errorf("Expected '%s' Got '%s'", WarnLevel, lv)
+	}
+}
+
+func TestVerbosityToggleDebug(t *testing.T) {
+	l := New()
+	buff := new(bytes.Buffer)
+	th := &testHandler{writer: buff}
+	l.AddHandler(th, NoticeLevel, ErrorLevel)
+	removed := &testHandler{writer: new(bytes.Buffer)}
+	l.AddHandler(removed, ErrorLevel)
+	v := NewVerbosityBuilder().WithInstance(l).WithRestoreAfter(time.Hour).Build()
+
+	v.ToggleDebug()
+	assertLevels(t, l.HandlerLevels(th), AllLevels)
+	assertLevels(t, l.HandlerLevels(removed), AllLevels)
+	if expected := "NOTICE debug logging enabled min_level=DEBUG restore_after=1h0m0s\n"; buff.String() != expected {
+		t.Errorf("Expected '%s' Got '%s'", expected, buff.String())
+	}
+
+	l.RemoveHandler(removed)
+	v.ToggleDebug()
+	assertLevels(t, l.HandlerLevels(th), []Level{NoticeLevel, ErrorLevel})
+	if levels := l.HandlerLevels(removed); len(levels) != 0 {
+		t.Errorf("Expected removed handler to stay removed Got '%v'", levels)
+	}
+	if !strings.HasSuffix(buff.String(), "NOTICE log verbosity restored\n") {
+		t.Errorf("Expected restored notice Got '%s'", buff.String())
+	}
+}
+
+func TestVerbosityRestoreAfter(t *testing.T) {
+	l := New()
+	th := &testHandler{writer: new(bytes.Buffer)}
+	l.AddHandler(th, ErrorLevel)
+	v := NewVerbosityBuilder().WithInstance(l).WithRestoreAfter(20 * time.Millisecond).Build()
+	defer v.Stop()
+
+	v.StepDown()
+	assertLevels(t, l.HandlerLevels(th), LevelsAtLeast(WarnLevel))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(l.HandlerLevels(th)) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected levels to be restored Got '%v'", l.HandlerLevels(th))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	assertLevels(t, l.HandlerLevels(th), []Level{ErrorLevel})
+}
@@ -0,0 +1,48 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package log
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Notify starts changing verbosity when the process receives SIGUSR1 or SIGUSR2 until Stop is called.
+// SIGUSR1 steps down, making logging more verbose, and SIGUSR2 steps up; when built using
+// WithDebugToggle SIGUSR1 toggles Debug and SIGUSR2 restores the previous levels.
+func (v *Verbosity) Notify() error {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	if v.stop != nil {
+		return nil
+	}
+	signals := make(chan os.Signal, 1)
+	stop := make(chan struct{})
+	v.stop = stop
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-stop:
+				return
+			case sig := <-signals:
+				switch {
+				case sig == syscall.SIGUSR1 && v.debugToggle:
+					v.ToggleDebug()
+				case sig == syscall.SIGUSR1:
+					v.StepDown()
+				case v.debugToggle:
+					v.Restore()
+				default:
+					v.StepUp()
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,53 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package log
+
+import (
+	"bytes"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestVerbosityNotify(t *testing.T) {
+	l := New()
+	th := &testHandler{writer: new(bytes.Buffer)}
+	l.AddHandler(th, ErrorLevel)
+	v := NewVerbosityBuilder().WithInstance(l).WithRestoreAfter(0).Build()
+	if err := v.Notify(); err != nil {
+		t.Fatalf("Unexpected error '%s'", err)
+	}
+
+	waitFor := func(expected []Level) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			levels := l.HandlerLevels(th)
+			if len(levels) == len(expected) && levels[0] == expected[0] {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected '%v' Got '%v'", expected, levels)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(LevelsAtLeast(WarnLevel))
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+	waitFor([]Level{ErrorLevel})
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(LevelsAtLeast(WarnLevel))
+	v.Stop()
+	assertLevels(t, l.HandlerLevels(th), []Level{ErrorLevel})
+}